@@ -4,6 +4,7 @@ go 1.22.3
 
 require (
 	github.com/ARUMANDESU/uniclubs-protos v0.9.1
+	github.com/alicebob/miniredis/v2 v2.39.0
 	github.com/centrifugal/centrifuge v0.32.2
 	github.com/go-ozzo/ozzo-validation/v4 v4.3.0
 	github.com/golang-jwt/jwt/v5 v5.2.1
@@ -14,6 +15,7 @@ require (
 	github.com/stretchr/testify v1.9.0
 	github.com/thejerf/slogassert v0.3.2
 	go.mongodb.org/mongo-driver v1.16.0
+	golang.org/x/time v0.5.0
 	google.golang.org/grpc v1.65.0
 )
 
@@ -46,6 +48,7 @@ require (
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
 	github.com/youmark/pkcs8 v0.0.0-20240424034433-3c2c7870ae76 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
 	golang.org/x/crypto v0.23.0 // indirect
 	golang.org/x/net v0.25.0 // indirect
 	golang.org/x/sync v0.7.0 // indirect
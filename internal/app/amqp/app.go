@@ -16,11 +16,13 @@ type App struct {
 	log        *slog.Logger
 	amqp       Amqp
 	usrService UserService
+	banService BanService
 }
 
 //go:generate mockery --name Amqp
 type Amqp interface {
 	Consume(queue string, routingKey string, handler func(msg amqp091.Delivery) error) error
+	UserBannedQueue() string
 	Close() error
 }
 
@@ -29,16 +31,23 @@ type UserService interface {
 	Update(ctx context.Context, user domain.User) error
 }
 
-func New(log *slog.Logger, userService UserService, amqp Amqp) *App {
+//go:generate mockery --name BanService
+type BanService interface {
+	Ban(ctx context.Context, ban domain.Ban) error
+}
+
+func New(log *slog.Logger, userService UserService, banService BanService, amqp Amqp) *App {
 	return &App{
 		log:        log,
 		amqp:       amqp,
 		usrService: userService,
+		banService: banService,
 	}
 }
 
 func (a *App) Start(_ context.Context, _ func(error)) {
 	a.consumeMessages(rabbitmq.UserEventsQueue, rabbitmq.UserUpdatedEventRoutingKey, a.HandleUpdateUser)
+	a.consumeMessages(a.amqp.UserBannedQueue(), rabbitmq.UserBannedEventRoutingKey, a.HandleUserBanned)
 }
 
 func (a *App) consumeMessages(queue, routingKey string, handler rabbitmq.Handler) {
@@ -87,3 +96,28 @@ func (a *App) HandleUpdateUser(msg amqp091.Delivery) error {
 
 	return nil
 }
+
+// HandleUserBanned revokes the access of the banned user and disconnects its active websocket connections
+func (a *App) HandleUserBanned(msg amqp091.Delivery) error {
+	const op = "amqp.app.handle-user-banned"
+	log := a.log.With(slog.String("op", op))
+
+	var ban domain.Ban
+
+	err := json.Unmarshal(msg.Body, &ban)
+	if err != nil {
+		log.Error("failed to unmarshal message", logger.Err(err))
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = a.banService.Ban(ctx, ban)
+	if err != nil {
+		log.Error("failed to ban user", logger.Err(err))
+		return err
+	}
+
+	return nil
+}
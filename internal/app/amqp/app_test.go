@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/app/amqp/mocks"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/rabbitmq"
 	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/assert"
@@ -18,14 +20,16 @@ type Suite struct {
 	App             *App
 	mockAmqp        *mocks.Amqp
 	mockUserService *mocks.UserService
+	mockBanService  *mocks.BanService
 }
 
 func NewSuite(t *testing.T) *Suite {
 	s := &Suite{
 		mockAmqp:        mocks.NewAmqp(t),
 		mockUserService: mocks.NewUserService(t),
+		mockBanService:  mocks.NewBanService(t),
 	}
-	s.App = New(logger.Plug(), s.mockUserService, s.mockAmqp)
+	s.App = New(logger.Plug(), s.mockUserService, s.mockBanService, s.mockAmqp)
 	return s
 }
 
@@ -65,6 +69,42 @@ func TestApp_HandleUpdateUser(t *testing.T) {
 	})
 }
 
+func TestApp_HandleUserBanned(t *testing.T) {
+	until := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("successful user ban", func(t *testing.T) {
+		suite := NewSuite(t)
+		ban := domain.Ban{UserID: 1, Reason: "spam", Until: &until}
+		msg := amqp.Delivery{Body: []byte(`{"user_id":1,"reason":"spam","until":"2030-01-01T00:00:00Z"}`)}
+
+		suite.mockBanService.On("Ban", mock.Anything, ban).Return(nil)
+
+		err := suite.App.HandleUserBanned(msg)
+		assert.NoError(t, err)
+		suite.mockBanService.AssertExpectations(t)
+	})
+
+	t.Run("failed JSON unmarshal", func(t *testing.T) {
+		suite := NewSuite(t)
+		msg := amqp.Delivery{Body: []byte(`invalid json`)}
+
+		err := suite.App.HandleUserBanned(msg)
+		assert.Error(t, err)
+		suite.mockBanService.AssertNotCalled(t, "Ban", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed user ban", func(t *testing.T) {
+		suite := NewSuite(t)
+		msg := amqp.Delivery{Body: []byte(`{"user_id":1}`)}
+
+		suite.mockBanService.On("Ban", mock.Anything, domain.Ban{UserID: 1}).Return(fmt.Errorf("ban error"))
+
+		err := suite.App.HandleUserBanned(msg)
+		assert.Error(t, err)
+		suite.mockBanService.AssertExpectations(t)
+	})
+}
+
 func TestApp_ConsumeMessages(t *testing.T) {
 
 	t.Run("successful message consumption", func(t *testing.T) {
@@ -112,6 +152,22 @@ func TestApp_ConsumeMessages(t *testing.T) {
 	})
 }
 
+func TestApp_Start(t *testing.T) {
+	suite := NewSuite(t)
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	done := func(mock.Arguments) { wg.Done() }
+	suite.mockAmqp.On("UserBannedQueue").Return("amq.gen-instance")
+	suite.mockAmqp.On("Consume", rabbitmq.UserEventsQueue, rabbitmq.UserUpdatedEventRoutingKey, mock.Anything).Return(nil).Run(done)
+	// the bans are consumed from the queue of this instance
+	suite.mockAmqp.On("Consume", "amq.gen-instance", rabbitmq.UserBannedEventRoutingKey, mock.Anything).Return(nil).Run(done)
+
+	suite.App.Start(context.Background(), nil)
+	wg.Wait()
+	suite.mockAmqp.AssertExpectations(t)
+}
+
 func TestApp_Stop(t *testing.T) {
 	t.Run("successful stop", func(t *testing.T) {
 		suite := NewSuite(t)
@@ -49,6 +49,24 @@ func (_m *Amqp) Consume(queue string, routingKey string, handler func(amqp091.De
 	return r0
 }
 
+// UserBannedQueue provides a mock function with given fields:
+func (_m *Amqp) UserBannedQueue() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserBannedQueue")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // NewAmqp creates a new instance of Amqp. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAmqp(t interface {
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BanService is an autogenerated mock type for the BanService type
+type BanService struct {
+	mock.Mock
+}
+
+// Ban provides a mock function with given fields: ctx, ban
+func (_m *BanService) Ban(ctx context.Context, ban domain.Ban) error {
+	ret := _m.Called(ctx, ban)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ban")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Ban) error); ok {
+		r0 = rf(ctx, ban)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewBanService creates a new instance of BanService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBanService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BanService {
+	mock := &BanService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
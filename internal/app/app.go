@@ -2,22 +2,34 @@ package app
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"sync"
 
 	amqpapp "github.com/ARUMANDESU/uniclubs-comments-service/internal/app/amqp"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/app/grpcapp"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/app/httpapp"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/app/outboxapp"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/app/purgeapp"
+	clubclient "github.com/ARUMANDESU/uniclubs-comments-service/internal/client/club"
+	postclient "github.com/ARUMANDESU/uniclubs-comments-service/internal/client/post"
 	userclient "github.com/ARUMANDESU/uniclubs-comments-service/internal/client/user"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/events"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/grpc/commentgrpc"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/handlers"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/moderation"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/rabbitmq"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ratelimit"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/permissionservice"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/revocationservice"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/userservice"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/storage/mongodb"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ws"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/jwt"
 	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/origin"
 )
 
 type App struct {
@@ -63,6 +75,20 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) *App {
 		panic(err)
 	}
 
+	// post microservice grpc client
+	postClient, err := postclient.New(log, cfg.Clients.Post.Address, cfg.Clients.Post.Timeout, cfg.Clients.Post.RetriesCount)
+	if err != nil {
+		log.Error("post service client init error", logger.Err(err))
+		panic(err)
+	}
+
+	// club microservice grpc client
+	clubClient, err := clubclient.New(log, cfg.Clients.Club.Address, cfg.Clients.Club.Timeout, cfg.Clients.Club.RetriesCount)
+	if err != nil {
+		log.Error("club service client init error", logger.Err(err))
+		panic(err)
+	}
+
 	rmq, err := rabbitmq.New(cfg.Rabbitmq, log)
 	if err != nil {
 		log.Error("failed to connect to rabbitmq", logger.Err(err))
@@ -70,24 +96,70 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) *App {
 	}
 
 	userService := userservice.New(log, &mongoStorage, userClient)
+	permissionService := permissionservice.New(log, userClient, postClient, clubClient)
+	dispatcher := events.NewDispatcher(log)
+
+	moderationPipeline, err := newModerationPipeline(cfg.Moderation)
+	if err != nil {
+		l.Error("failed to create moderation pipeline", logger.Err(err))
+		panic(err)
+	}
+
+	// the bans are persisted so every instance rejects the banned users, whichever received the ban
+	revocationService := revocationservice.New(log, &mongoStorage, cfg.Revocation.SyncInterval)
+	starters = append(starters, revocationService)
+	stoppers = append(stoppers, revocationService)
 
 	commentService := commentservice.New(commentservice.Config{
-		Logger:       log,
-		Provider:     &mongoStorage,
-		Creator:      &mongoStorage,
-		Updater:      &mongoStorage,
-		Deleter:      &mongoStorage,
-		UserProvider: &userService,
+		Logger:           log,
+		Provider:         &mongoStorage,
+		Creator:          &mongoStorage,
+		Updater:          &mongoStorage,
+		Deleter:          &mongoStorage,
+		Reactor:          &mongoStorage,
+		RevisionKeeper:   &mongoStorage,
+		UserProvider:     &userService,
+		ModerationPolicy: permissionService,
+		Publisher:        dispatcher,
+		Outbox:           events.NewOutbox(&mongoStorage),
+		Transactor:       &mongoStorage,
+		RateLimiter:      ratelimit.New(cfg.RateLimit),
+		ContentModerator: moderationPipeline,
+		Reports:          &mongoStorage,
+		ClubResolver:     postClient,
+		ReportsToHide:    cfg.Moderation.ReportsToHide,
+		Participants:     &mongoStorage,
+		AccessChecker:    permissionService,
+		Bans:             revocationService,
 	})
 
-	wsManager, err := ws.NewManager(log, commentService)
+	purgeApp := purgeapp.New(log, commentService, cfg.Comments.DeletedRetention, cfg.Comments.PurgeInterval)
+	starters = append(starters, purgeApp)
+	stoppers = append(stoppers, purgeApp)
+
+	tokenVerifier, err := newTokenVerifier(cfg.JWT)
+	if err != nil {
+		l.Error("failed to create token verifier", logger.Err(err))
+		panic(err)
+	}
+
+	wsManager, err := ws.NewManager(log, cfg.Broker, commentService, permissionService, tokenVerifier, revocationService)
 	if err != nil {
 		l.Error("failed to create websocket manager", logger.Err(err))
 		panic(err)
 	}
 	stoppers = append(stoppers, wsManager)
+	revocationService.SetDisconnector(wsManager)
+	dispatcher.Subscribe(wsManager)
+
+	// browsers of local and dev frontends may send no origin, production requires it
+	origins, err := origin.NewChecker(cfg.HTTP.AllowedOrigins, cfg.Env != config.EnvProd)
+	if err != nil {
+		l.Error("failed to parse allowed origins", logger.Err(err))
+		panic(err)
+	}
 
-	handler := handlers.NewHandler(log, wsManager)
+	handler := handlers.NewHandler(log, wsManager, commentService, permissionService, tokenVerifier, origins)
 	handler.RegisterRoutes()
 
 	httpServer := httpapp.New(cfg, log, handler.Mux)
@@ -100,7 +172,12 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) *App {
 	starters = append(starters, grpcApp)
 	stoppers = append(stoppers, grpcApp)
 
-	rabbitmqApp := amqpapp.New(log, &userService, rmq)
+	// the events reach the comment exchange through the outbox, so a broker outage delays them but loses none
+	outboxApp := outboxapp.New(log, &mongoStorage, rmq, cfg.Outbox.RelayInterval, cfg.Outbox.BatchSize, cfg.Outbox.MaxBackoff)
+	starters = append(starters, outboxApp)
+	stoppers = append(stoppers, outboxApp)
+
+	rabbitmqApp := amqpapp.New(log, &userService, revocationService, rmq)
 	starters = append(starters, rabbitmqApp)
 	stoppers = append(stoppers, rabbitmqApp)
 
@@ -112,6 +189,33 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) *App {
 	}
 }
 
+// newTokenVerifier returns the verifier of the first configured key source
+func newTokenVerifier(cfg config.JWT) (*jwt.Verifier, error) {
+	var (
+		verifier *jwt.Verifier
+		err      error
+	)
+	switch {
+	case cfg.JWKSURL != "":
+		verifier = jwt.NewJWKSVerifier(jwt.NewJWKS(cfg.JWKSURL, cfg.JWKSRefreshInterval))
+	case cfg.PublicKeyFile != "":
+		verifier, err = jwt.NewPublicKeyFileVerifier(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	case cfg.Secret != "":
+		verifier = jwt.NewHMACVerifier(cfg.Secret)
+	default:
+		return nil, errors.New("no jwt key source is configured, set JWT_JWKS_URL, JWT_PUBLIC_KEY_FILE or JWT_SECRET")
+	}
+
+	return verifier.WithValidation(jwt.Validation{
+		Issuer:   cfg.Issuer,
+		Audience: cfg.Audience,
+		Leeway:   cfg.Leeway,
+	}), nil
+}
+
 func (a *App) Start() error {
 	const op = "app.start"
 	log := a.log.With(slog.String("op", op))
@@ -185,3 +289,35 @@ func (a *App) Stop() {
 	// Wait for all services to stop
 	wg.Wait()
 }
+
+// newModerationPipeline returns the pipeline of the configured content checks
+func newModerationPipeline(cfg config.Moderation) (commentservice.ModerationPipeline, error) {
+	bannedWordsStatus, err := moderation.ParseAction(cfg.BannedWordsAction)
+	if err != nil {
+		return commentservice.ModerationPipeline{}, err
+	}
+	linksStatus, err := moderation.ParseAction(cfg.LinksAction)
+	if err != nil {
+		return commentservice.ModerationPipeline{}, err
+	}
+	spamStatus, err := moderation.ParseAction(cfg.SpamAction)
+	if err != nil {
+		return commentservice.ModerationPipeline{}, err
+	}
+
+	bannedWords := cfg.BannedWords
+	if cfg.BannedWordsFile != "" {
+		words, err := moderation.LoadWords(cfg.BannedWordsFile)
+		if err != nil {
+			return commentservice.ModerationPipeline{}, err
+		}
+		bannedWords = append(bannedWords, words...)
+	}
+
+	return commentservice.NewModerationPipeline(
+		moderation.NewLengthCheck(cfg.MaxLength),
+		moderation.NewBannedWordsCheck(bannedWords, bannedWordsStatus),
+		moderation.NewLinkCheck(cfg.MaxLinks, linksStatus),
+		moderation.NewSpamCheck(cfg.SpamWindow, cfg.SpamRepeats, spamStatus),
+	), nil
+}
@@ -0,0 +1,171 @@
+package outboxapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+)
+
+// claimLease is how long the other relays skip a message claimed for publishing, it bounds a publish attempt
+const claimLease = 30 * time.Second
+
+// markTimeout bounds recording the result of a publish attempt, it gets its own context
+// so a publish that used up the lease can still be recorded
+const markTimeout = 5 * time.Second
+
+// App periodically relays the pending outbox messages to the message broker,
+// a message that fails to publish is retried with an exponential backoff until the broker accepts it
+type App struct {
+	log        *slog.Logger
+	storage    Storage
+	publisher  Publisher
+	interval   time.Duration
+	batchSize  int
+	maxBackoff time.Duration
+
+	started atomic.Bool
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+//go:generate mockery --name Storage
+type Storage interface {
+	ClaimOutboxMessage(ctx context.Context, now time.Time, lease time.Duration) (domain.OutboxMessage, error)
+	MarkOutboxMessageSent(ctx context.Context, id string, sentAt time.Time) error
+	MarkOutboxMessageFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error
+}
+
+// Publisher sends the message to the exchange of the message broker, it is implemented by rabbitmq.Rabbitmq
+//
+//go:generate mockery --name Publisher
+type Publisher interface {
+	Publish(ctx context.Context, exchangeName string, routingKey string, msg any) error
+}
+
+func New(log *slog.Logger, storage Storage, publisher Publisher, interval time.Duration, batchSize int, maxBackoff time.Duration) *App {
+	return &App{
+		log:        log,
+		storage:    storage,
+		publisher:  publisher,
+		interval:   interval,
+		batchSize:  batchSize,
+		maxBackoff: maxBackoff,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start runs the relay in the background, the first relay happens after one interval
+func (a *App) Start(_ context.Context, _ func(error)) {
+	a.started.Store(true)
+
+	go func() {
+		defer close(a.done)
+
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-a.stop:
+				return
+			case <-ticker.C:
+				a.relay()
+			}
+		}
+	}()
+}
+
+// relay publishes up to a batch of due messages, it stops at the first failure since the broker is likely unavailable
+func (a *App) relay() {
+	const op = "app.outbox.relay"
+	log := a.log.With(slog.String("op", op))
+
+	for i := 0; i < a.batchSize; i++ {
+		select {
+		case <-a.stop:
+			return
+		default:
+		}
+
+		message, err := a.storage.ClaimOutboxMessage(context.Background(), time.Now(), claimLease)
+		if err != nil {
+			if !errors.Is(err, domain.ErrOutboxEmpty) {
+				log.Error("failed to claim outbox message", logger.Err(err))
+			}
+			return
+		}
+
+		if !a.publish(log, message) {
+			return
+		}
+	}
+}
+
+// publish sends the message and records the outcome, it reports whether the broker accepted the message
+func (a *App) publish(log *slog.Logger, message domain.OutboxMessage) bool {
+	log = log.With(slog.String("message_id", message.ID), slog.String("routing_key", message.RoutingKey))
+
+	ctx, cancel := context.WithTimeout(context.Background(), claimLease)
+	defer cancel()
+
+	err := a.publisher.Publish(ctx, message.Exchange, message.RoutingKey, json.RawMessage(message.Payload))
+
+	markCtx, markCancel := context.WithTimeout(context.Background(), markTimeout)
+	defer markCancel()
+
+	if err != nil {
+		nextAttemptAt := time.Now().Add(a.backoff(message.Attempts + 1))
+		log.Warn("failed to publish outbox message", slog.Int("attempts", message.Attempts+1), logger.Err(err))
+
+		err = a.storage.MarkOutboxMessageFailed(markCtx, message.ID, err.Error(), nextAttemptAt)
+		if err != nil {
+			log.Error("failed to mark outbox message as failed", logger.Err(err))
+		}
+		return false
+	}
+
+	// the message is published again once the lease expires if it can not be marked, consumers drop the duplicates
+	err = a.storage.MarkOutboxMessageSent(markCtx, message.ID, time.Now())
+	if err != nil {
+		log.Error("failed to mark outbox message as sent", logger.Err(err))
+	}
+
+	return true
+}
+
+// backoff returns the delay after the failed attempt, it doubles with every attempt up to the max backoff
+func (a *App) backoff(attempts int) time.Duration {
+	delay := a.interval
+	for i := 1; i < attempts && delay < a.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, a.maxBackoff)
+}
+
+// Stop stops the relay and waits for the running relay to finish
+func (a *App) Stop(ctx context.Context) error {
+	const op = "app.outbox.stop"
+
+	a.log.With(slog.String("op", op)).Info("stopping outbox relay")
+	a.once.Do(func() { close(a.stop) })
+
+	if !a.started.Load() {
+		return nil
+	}
+
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,122 @@
+package outboxapp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/app/outboxapp/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func outboxMessage(id string) domain.OutboxMessage {
+	return domain.OutboxMessage{
+		ID:         id,
+		Exchange:   "comment-exchange",
+		RoutingKey: "comment.event.created",
+		Payload:    []byte(`{"id":"` + id + `"}`),
+		Status:     domain.OutboxPending,
+	}
+}
+
+func TestApp_RelaysPendingMessages(t *testing.T) {
+	storage := mocks.NewStorage(t)
+	publisher := mocks.NewPublisher(t)
+	sent := make(chan struct{}, 2)
+
+	storage.On("ClaimOutboxMessage", mock.Anything, mock.Anything, claimLease).Return(outboxMessage("1"), nil).Once()
+	storage.On("ClaimOutboxMessage", mock.Anything, mock.Anything, claimLease).Return(outboxMessage("2"), nil).Once()
+	storage.On("ClaimOutboxMessage", mock.Anything, mock.Anything, claimLease).Return(domain.OutboxMessage{}, domain.ErrOutboxEmpty)
+	publisher.On("Publish", mock.Anything, "comment-exchange", "comment.event.created", json.RawMessage(`{"id":"1"}`)).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, "comment-exchange", "comment.event.created", json.RawMessage(`{"id":"2"}`)).Return(nil).Once()
+	storage.On("MarkOutboxMessageSent", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		sent <- struct{}{}
+	}).Twice()
+
+	app := New(logger.Plug(), storage, publisher, 10*time.Millisecond, 10, time.Second)
+	app.Start(context.Background(), nil)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-sent:
+		case <-time.After(time.Second):
+			t.Fatal("message was not relayed")
+		}
+	}
+
+	err := app.Stop(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestApp_RetriesFailedMessages(t *testing.T) {
+	storage := mocks.NewStorage(t)
+	publisher := mocks.NewPublisher(t)
+	failed := make(chan struct{}, 1)
+
+	message := outboxMessage("1")
+	message.Attempts = 2
+
+	storage.On("ClaimOutboxMessage", mock.Anything, mock.Anything, claimLease).Return(message, nil)
+	publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError)
+	storage.On("MarkOutboxMessageFailed", mock.Anything, "1", assert.AnError.Error(), mock.MatchedBy(func(nextAttemptAt time.Time) bool {
+		// the third failure waits four intervals
+		return time.Until(nextAttemptAt) > 30*time.Millisecond
+	})).Return(nil).Run(func(args mock.Arguments) {
+		select {
+		case failed <- struct{}{}:
+		default:
+		}
+	})
+
+	app := New(logger.Plug(), storage, publisher, 10*time.Millisecond, 10, time.Second)
+	app.Start(context.Background(), nil)
+
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Fatal("failure was not recorded")
+	}
+
+	err := app.Stop(context.Background())
+	assert.NoError(t, err)
+	// the batch stops at the first failure
+	storage.AssertNotCalled(t, "MarkOutboxMessageSent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApp_MarksFailedAfterPublishTimeout(t *testing.T) {
+	storage := mocks.NewStorage(t)
+	publisher := mocks.NewPublisher(t)
+	app := New(logger.Plug(), storage, publisher, time.Second, 10, time.Minute)
+
+	// the publish gives up when its context expires, the failure is recorded with another one
+	var publishCtx context.Context
+	publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(context.DeadlineExceeded).Run(func(args mock.Arguments) {
+		publishCtx = args.Get(0).(context.Context)
+	})
+	storage.On("MarkOutboxMessageFailed", mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx != publishCtx && ctx.Err() == nil
+	}), "1", context.DeadlineExceeded.Error(), mock.Anything).Return(nil)
+
+	assert.False(t, app.publish(logger.Plug(), outboxMessage("1")))
+}
+
+func TestApp_Backoff(t *testing.T) {
+	app := New(logger.Plug(), nil, nil, time.Second, 10, time.Minute)
+
+	assert.Equal(t, time.Second, app.backoff(1))
+	assert.Equal(t, 2*time.Second, app.backoff(2))
+	assert.Equal(t, 32*time.Second, app.backoff(6))
+	assert.Equal(t, time.Minute, app.backoff(7))
+	assert.Equal(t, time.Minute, app.backoff(1000))
+}
+
+func TestApp_StopWithoutStart(t *testing.T) {
+	app := New(logger.Plug(), mocks.NewStorage(t), mocks.NewPublisher(t), time.Hour, 10, time.Hour)
+
+	err := app.Stop(context.Background())
+	assert.NoError(t, err)
+}
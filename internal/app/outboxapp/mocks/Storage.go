@@ -0,0 +1,95 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Storage is an autogenerated mock type for the Storage type
+type Storage struct {
+	mock.Mock
+}
+
+// ClaimOutboxMessage provides a mock function with given fields: ctx, now, lease
+func (_m *Storage) ClaimOutboxMessage(ctx context.Context, now time.Time, lease time.Duration) (domain.OutboxMessage, error) {
+	ret := _m.Called(ctx, now, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimOutboxMessage")
+	}
+
+	var r0 domain.OutboxMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) (domain.OutboxMessage, error)); ok {
+		return rf(ctx, now, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) domain.OutboxMessage); ok {
+		r0 = rf(ctx, now, lease)
+	} else {
+		r0 = ret.Get(0).(domain.OutboxMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, now, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkOutboxMessageFailed provides a mock function with given fields: ctx, id, lastError, nextAttemptAt
+func (_m *Storage) MarkOutboxMessageFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	ret := _m.Called(ctx, id, lastError, nextAttemptAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOutboxMessageFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, id, lastError, nextAttemptAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkOutboxMessageSent provides a mock function with given fields: ctx, id, sentAt
+func (_m *Storage) MarkOutboxMessageSent(ctx context.Context, id string, sentAt time.Time) error {
+	ret := _m.Called(ctx, id, sentAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOutboxMessageSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, sentAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStorage creates a new instance of Storage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Storage {
+	mock := &Storage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+package purgeapp
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+)
+
+// App periodically hard deletes soft deleted comments older than the retention period
+type App struct {
+	log       *slog.Logger
+	purger    Purger
+	retention time.Duration
+	interval  time.Duration
+
+	started atomic.Bool
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+//go:generate mockery --name Purger
+type Purger interface {
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+func New(log *slog.Logger, purger Purger, retention, interval time.Duration) *App {
+	return &App{
+		log:       log,
+		purger:    purger,
+		retention: retention,
+		interval:  interval,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start runs the purge job in the background, the first purge happens after one interval
+func (a *App) Start(_ context.Context, _ func(error)) {
+	a.started.Store(true)
+
+	go func() {
+		defer close(a.done)
+
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-a.stop:
+				return
+			case <-ticker.C:
+				a.purge()
+			}
+		}
+	}()
+}
+
+func (a *App) purge() {
+	const op = "app.purge.purge"
+	log := a.log.With(slog.String("op", op))
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.interval)
+	defer cancel()
+
+	purged, err := a.purger.PurgeDeleted(ctx, a.retention)
+	if err != nil {
+		log.Error("failed to purge deleted comments", logger.Err(err), slog.Int64("purged", purged))
+		return
+	}
+
+	if purged > 0 {
+		log.Info("purged deleted comments", slog.Int64("purged", purged))
+	}
+}
+
+// Stop stops the purge job and waits for the running purge to finish
+func (a *App) Stop(ctx context.Context) error {
+	const op = "app.purge.stop"
+
+	a.log.With(slog.String("op", op)).Info("stopping purge job")
+	a.once.Do(func() { close(a.stop) })
+
+	if !a.started.Load() {
+		return nil
+	}
+
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
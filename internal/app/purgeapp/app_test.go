@@ -0,0 +1,69 @@
+package purgeapp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/app/purgeapp/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApp_PurgesPeriodically(t *testing.T) {
+	purger := mocks.NewPurger(t)
+	purged := make(chan struct{}, 1)
+	purger.On("PurgeDeleted", mock.Anything, time.Hour).Return(int64(1), nil).Run(func(args mock.Arguments) {
+		select {
+		case purged <- struct{}{}:
+		default:
+		}
+	})
+
+	app := New(logger.Plug(), purger, time.Hour, 10*time.Millisecond)
+	app.Start(context.Background(), func(err error) {
+		assert.NoError(t, err)
+	})
+
+	select {
+	case <-purged:
+	case <-time.After(time.Second):
+		t.Fatal("purge was not called")
+	}
+
+	err := app.Stop(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestApp_KeepsRunningWhenPurgeFails(t *testing.T) {
+	purger := mocks.NewPurger(t)
+	calls := make(chan struct{}, 2)
+	purger.On("PurgeDeleted", mock.Anything, time.Hour).Return(int64(0), assert.AnError).Run(func(args mock.Arguments) {
+		select {
+		case calls <- struct{}{}:
+		default:
+		}
+	})
+
+	app := New(logger.Plug(), purger, time.Hour, 10*time.Millisecond)
+	app.Start(context.Background(), nil)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-calls:
+		case <-time.After(time.Second):
+			t.Fatal("purge was not retried")
+		}
+	}
+
+	err := app.Stop(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestApp_StopWithoutStart(t *testing.T) {
+	app := New(logger.Plug(), mocks.NewPurger(t), time.Hour, time.Hour)
+
+	err := app.Stop(context.Background())
+	assert.NoError(t, err)
+}
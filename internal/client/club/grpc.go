@@ -0,0 +1,114 @@
+package clubclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	grpclog "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type Client struct {
+	clubv1.ClubClient
+	log *slog.Logger
+}
+
+func New(
+	log *slog.Logger,
+	addr string,
+	timeout time.Duration,
+	retriesCount int,
+) (*Client, error) {
+	const op = "grpc.New"
+
+	retryOpts := []grpcretry.CallOption{
+		grpcretry.WithCodes(codes.Unavailable, codes.Aborted),
+		grpcretry.WithMax(uint(retriesCount)),
+		grpcretry.WithPerRetryTimeout(timeout),
+	}
+
+	logOpts := []grpclog.Option{
+		grpclog.WithLogOnEvents(grpclog.StartCall, grpclog.FinishCall),
+	}
+
+	cc, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			grpclog.UnaryClientInterceptor(InterceptorLogger(log), logOpts...),
+			grpcretry.UnaryClientInterceptor(retryOpts...),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{
+		ClubClient: clubv1.NewClubClient(cc),
+		log:        log,
+	}, nil
+}
+
+// CanManagePosts reports whether the user is allowed to manage the posts of the club
+func (c *Client) CanManagePosts(ctx context.Context, clubID, userID int64) (bool, error) {
+	const op = "client.club.can_manage_posts"
+	log := c.log.With(slog.String("op", op))
+
+	resp, err := c.ClubClient.HavePermissionTo(ctx, &clubv1.HavePermissionToRequest{
+		ClubId:     clubID,
+		UserId:     userID,
+		Permission: clubv1.Permission_PERMISSION_MANAGE_POSTS,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			return false, domain.ErrInvalidArg
+		case status.Code(err) == codes.NotFound:
+			return false, nil
+		default:
+			log.Error("internal", logger.Err(err))
+			return false, err
+		}
+	}
+
+	return resp.GetHasPermission(), nil
+}
+
+// IsMember reports whether the user is a member of the club
+func (c *Client) IsMember(ctx context.Context, clubID, userID int64) (bool, error) {
+	const op = "client.club.is_member"
+	log := c.log.With(slog.String("op", op))
+
+	resp, err := c.ClubClient.GetJoinStatus(ctx, &clubv1.GetJoinStatusRequest{
+		ClubId: clubID,
+		UserId: userID,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			return false, domain.ErrInvalidArg
+		case status.Code(err) == codes.NotFound:
+			return false, domain.ErrClubNotFound
+		default:
+			log.Error("internal", logger.Err(err))
+			return false, err
+		}
+	}
+
+	return resp.GetStatus() == clubv1.JoinStatus_MEMBER, nil
+}
+
+// InterceptorLogger adapts slog logger to interceptor logger
+func InterceptorLogger(l *slog.Logger) grpclog.Logger {
+	return grpclog.LoggerFunc(func(ctx context.Context, lvl grpclog.Level, msg string, fields ...any) {
+		l.Log(ctx, slog.Level(lvl), msg, fields...)
+	})
+}
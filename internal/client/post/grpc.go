@@ -0,0 +1,109 @@
+package postclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	postv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/posts/post"
+	grpclog "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type Client struct {
+	postv1.PostClient
+	log *slog.Logger
+}
+
+func New(
+	log *slog.Logger,
+	addr string,
+	timeout time.Duration,
+	retriesCount int,
+) (*Client, error) {
+	const op = "grpc.New"
+
+	retryOpts := []grpcretry.CallOption{
+		grpcretry.WithCodes(codes.Unavailable, codes.Aborted),
+		grpcretry.WithMax(uint(retriesCount)),
+		grpcretry.WithPerRetryTimeout(timeout),
+	}
+
+	logOpts := []grpclog.Option{
+		grpclog.WithLogOnEvents(grpclog.StartCall, grpclog.FinishCall),
+	}
+
+	cc, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			grpclog.UnaryClientInterceptor(InterceptorLogger(log), logOpts...),
+			grpcretry.UnaryClientInterceptor(retryOpts...),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Client{
+		PostClient: postv1.NewPostClient(cc),
+		log:        log,
+	}, nil
+}
+
+// GetPostClubID returns the id of the club the post belongs to
+func (c *Client) GetPostClubID(ctx context.Context, postID string) (int64, error) {
+	const op = "client.post.get_post_club_id"
+	log := c.log.With(slog.String("op", op))
+
+	post, err := c.PostClient.GetPost(ctx, &postv1.GetPostRequest{Id: postID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			return 0, domain.ErrInvalidArg
+		case status.Code(err) == codes.NotFound:
+			return 0, domain.ErrPostNotFound
+		default:
+			log.Error("internal", logger.Err(err))
+			return 0, err
+		}
+	}
+
+	return post.GetClub().GetId(), nil
+}
+
+// CanViewPost reports whether the post exists and is visible to the user
+func (c *Client) CanViewPost(ctx context.Context, postID string, userID int64) (bool, error) {
+	const op = "client.post.can_view_post"
+	log := c.log.With(slog.String("op", op))
+
+	_, err := c.PostClient.GetPost(ctx, &postv1.GetPostRequest{Id: postID, UserId: userID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			return false, domain.ErrInvalidArg
+		case status.Code(err) == codes.NotFound:
+			return false, domain.ErrPostNotFound
+		case status.Code(err) == codes.PermissionDenied:
+			return false, nil
+		default:
+			log.Error("internal", logger.Err(err))
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// InterceptorLogger adapts slog logger to interceptor logger
+func InterceptorLogger(l *slog.Logger) grpclog.Logger {
+	return grpclog.LoggerFunc(func(ctx context.Context, lvl grpclog.Level, msg string, fields ...any) {
+		l.Log(ctx, slog.Level(lvl), msg, fields...)
+	})
+}
@@ -82,6 +82,30 @@ func (c *Client) GetUserByID(ctx context.Context, id int64) (domain.User, error)
 	}, nil
 }
 
+// IsModerator reports whether the user has a platform wide moderator or admin role
+func (c *Client) IsModerator(ctx context.Context, userID int64) (bool, error) {
+	const op = "client.user.is_moderator"
+	log := c.log.With(slog.String("op", op))
+
+	resp, err := c.UserClient.CheckUserRole(ctx, &userv1.CheckUserRoleRequest{
+		UserId: userID,
+		Roles:  []userv1.Role{userv1.Role_MODER, userv1.Role_ADMIN},
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			return false, domain.ErrInvalidArg
+		case status.Code(err) == codes.NotFound:
+			return false, domain.ErrUserNotFound
+		default:
+			log.Error("internal", logger.Err(err))
+			return false, err
+		}
+	}
+
+	return resp.GetHasRole(), nil
+}
+
 // InterceptorLogger adapts slog logger to interceptor logger
 func InterceptorLogger(l *slog.Logger) grpclog.Logger {
 	return grpclog.LoggerFunc(func(ctx context.Context, lvl grpclog.Level, msg string, fields ...any) {
@@ -9,6 +9,12 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+const (
+	EnvLocal = "local"
+	EnvDev   = "dev"
+	EnvProd  = "prod"
+)
+
 type Config struct {
 	Env             string        `yaml:"env" env:"ENV" env-default:"local"`
 	HTTP            HTTP          `yaml:"http"`
@@ -18,12 +24,22 @@ type Config struct {
 	Clients         ClientsConfig `yaml:"clients"`
 	GRPC            GRPC          `yaml:"grpc"`
 	Rabbitmq        Rabbitmq      `yaml:"rabbitmq"`
+	Comments        Comments      `yaml:"comments"`
+	Broker          Broker        `yaml:"broker"`
+	JWT             JWT           `yaml:"jwt"`
+	Revocation      Revocation    `yaml:"revocation"`
+	RateLimit       RateLimit     `yaml:"rate_limit"`
+	Moderation      Moderation    `yaml:"moderation"`
+	Outbox          Outbox        `yaml:"outbox"`
 }
 
 type HTTP struct {
 	Address     string        `yaml:"address" env:"HTTP_ADDRESS" env-default:"localhost:5000"`
 	Timeout     time.Duration `yaml:"timeout" env:"HTTP_TIMEOUT" env-default:"10s"`
 	IdleTimeout time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" env-default:"4s"`
+	// AllowedOrigins are the origins of the frontends allowed to open realtime connections,
+	// https://*.example.com allows every subdomain of example.com and * allows any origin
+	AllowedOrigins []string `yaml:"allowed_origins" env:"HTTP_ALLOWED_ORIGINS" env-separator:"," env-default:"http://localhost:3000"`
 }
 
 type GRPC struct {
@@ -44,12 +60,110 @@ type Rabbitmq struct {
 	Port     string `yaml:"port" env:"RABBITMQ_PORT"`
 }
 
+type Comments struct {
+	// DeletedRetention is how long soft deleted comments are kept before they are purged
+	DeletedRetention time.Duration `yaml:"deleted_retention" env:"COMMENTS_DELETED_RETENTION" env-default:"720h"`
+	PurgeInterval    time.Duration `yaml:"purge_interval" env:"COMMENTS_PURGE_INTERVAL" env-default:"1h"`
+}
+
+// Outbox configures the relay of the comment events from the outbox to the comment exchange of rabbitmq
+type Outbox struct {
+	RelayInterval time.Duration `yaml:"relay_interval" env:"OUTBOX_RELAY_INTERVAL" env-default:"1s"`
+	// BatchSize is how many messages are relayed at most per interval
+	BatchSize int `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+	// MaxBackoff caps the delay between the attempts to publish a message, it doubles after every failed attempt
+	MaxBackoff time.Duration `yaml:"max_backoff" env:"OUTBOX_MAX_BACKOFF" env-default:"5m"`
+}
+
+// Broker configures how websocket publications and presence are shared between the instances of the service
+type Broker struct {
+	// Type is memory for a single instance or redis to fan out between instances
+	Type  string `yaml:"type" env:"BROKER_TYPE" env-default:"memory"`
+	Redis Redis  `yaml:"redis"`
+}
+
+type Redis struct {
+	Address string `yaml:"address" env:"REDIS_ADDRESS" env-default:"localhost:6379"`
+	// ClusterAddresses are the seed nodes of a Redis Cluster, Address is ignored when they are set
+	ClusterAddresses []string `yaml:"cluster_addresses" env:"REDIS_CLUSTER_ADDRESSES" env-separator:","`
+	User             string   `yaml:"user" env:"REDIS_USER"`
+	Password         string   `yaml:"password" env:"REDIS_PASSWORD"`
+	DB               int      `yaml:"db" env:"REDIS_DB"`
+	// Prefix is put before every channel name and key in Redis
+	Prefix string `yaml:"prefix" env:"REDIS_PREFIX" env-default:"comments"`
+}
+
+// JWT configures how the user tokens are verified, the first configured key source of
+// JWKSURL, PublicKeyFile and Secret is used
+type JWT struct {
+	// Secret verifies HS256 tokens signed with the shared secret
+	Secret string `yaml:"secret" env:"JWT_SECRET"`
+	// PublicKeyFile is the path of the PEM encoded RSA or ECDSA public key verifying RS256 or ES256 tokens
+	PublicKeyFile string `yaml:"public_key_file" env:"JWT_PUBLIC_KEY_FILE"`
+	// JWKSURL is the endpoint of the key set verifying RS256 or ES256 tokens, keys are picked by the kid header
+	JWKSURL             string        `yaml:"jwks_url" env:"JWT_JWKS_URL"`
+	JWKSRefreshInterval time.Duration `yaml:"jwks_refresh_interval" env:"JWT_JWKS_REFRESH_INTERVAL" env-default:"1h"`
+	// Issuer is the expected iss claim, empty to skip the check
+	Issuer string `yaml:"issuer" env:"JWT_ISSUER"`
+	// Audience must be one of the aud claim values, empty to skip the check
+	Audience string `yaml:"audience" env:"JWT_AUDIENCE"`
+	// Leeway is the allowed clock skew when checking exp, nbf and iat
+	Leeway time.Duration `yaml:"leeway" env:"JWT_LEEWAY" env-default:"30s"`
+}
+
+// Revocation configures the store of the banned users, the bans are kept in MongoDB,
+// so they survive restarts and are shared between the instances
+type Revocation struct {
+	// SyncInterval is how often the persisted bans are reloaded into memory
+	SyncInterval time.Duration `yaml:"sync_interval" env:"REVOCATION_SYNC_INTERVAL" env-default:"1m"`
+}
+
+// RateLimit limits how often comments are created, rates are per second and a zero rate disables the bucket
+type RateLimit struct {
+	UserRate    float64 `yaml:"user_rate" env:"RATE_LIMIT_USER_RATE" env-default:"1"`
+	UserBurst   int     `yaml:"user_burst" env:"RATE_LIMIT_USER_BURST" env-default:"5"`
+	PostRate    float64 `yaml:"post_rate" env:"RATE_LIMIT_POST_RATE" env-default:"10"`
+	PostBurst   int     `yaml:"post_burst" env:"RATE_LIMIT_POST_BURST" env-default:"30"`
+	GlobalRate  float64 `yaml:"global_rate" env:"RATE_LIMIT_GLOBAL_RATE" env-default:"200"`
+	GlobalBurst int     `yaml:"global_burst" env:"RATE_LIMIT_GLOBAL_BURST" env-default:"400"`
+}
+
+// Moderation configures the checks of the comment bodies, an action is mask, review or reject
+type Moderation struct {
+	// MaxLength is the longest body in characters, empty bodies are always rejected
+	MaxLength int `yaml:"max_length" env:"MODERATION_MAX_LENGTH" env-default:"2000"`
+	// BannedWords are added to the words of BannedWordsFile, the file has a word per line
+	BannedWords       []string `yaml:"banned_words" env:"MODERATION_BANNED_WORDS" env-separator:","`
+	BannedWordsFile   string   `yaml:"banned_words_file" env:"MODERATION_BANNED_WORDS_FILE"`
+	BannedWordsAction string   `yaml:"banned_words_action" env:"MODERATION_BANNED_WORDS_ACTION" env-default:"mask"`
+	// MaxLinks is how many links a body can have before LinksAction applies
+	MaxLinks    int    `yaml:"max_links" env:"MODERATION_MAX_LINKS" env-default:"2"`
+	LinksAction string `yaml:"links_action" env:"MODERATION_LINKS_ACTION" env-default:"review"`
+	// SpamRepeats is how many times a user may send the same message within SpamWindow before SpamAction applies
+	SpamRepeats int           `yaml:"spam_repeats" env:"MODERATION_SPAM_REPEATS" env-default:"3"`
+	SpamWindow  time.Duration `yaml:"spam_window" env:"MODERATION_SPAM_WINDOW" env-default:"1m"`
+	SpamAction  string        `yaml:"spam_action" env:"MODERATION_SPAM_ACTION" env-default:"reject"`
+	// ReportsToHide is how many distinct users have to report a comment to hide it until a moderator reviews it,
+	// 0 never hides reported comments
+	ReportsToHide int `yaml:"reports_to_hide" env:"MODERATION_REPORTS_TO_HIDE" env-default:"3"`
+}
+
 type ClientsConfig struct {
 	User struct {
 		Address      string        `yaml:"address" env:"USER_SERVICE_ADDRESS"`
 		Timeout      time.Duration `yaml:"timeout" env:"USER_SERVICE_TIMEOUT"`
 		RetriesCount int           `yaml:"retries_count" env:"USER_SERVICE_RETRIES_COUNT"`
 	} `yaml:"user"`
+	Post struct {
+		Address      string        `yaml:"address" env:"POST_SERVICE_ADDRESS"`
+		Timeout      time.Duration `yaml:"timeout" env:"POST_SERVICE_TIMEOUT"`
+		RetriesCount int           `yaml:"retries_count" env:"POST_SERVICE_RETRIES_COUNT"`
+	} `yaml:"post"`
+	Club struct {
+		Address      string        `yaml:"address" env:"CLUB_SERVICE_ADDRESS"`
+		Timeout      time.Duration `yaml:"timeout" env:"CLUB_SERVICE_TIMEOUT"`
+		RetriesCount int           `yaml:"retries_count" env:"CLUB_SERVICE_RETRIES_COUNT"`
+	} `yaml:"club"`
 }
 
 func MustLoad() *Config {
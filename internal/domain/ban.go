@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Ban revokes the access of the user to the realtime API until it expires
+type Ban struct {
+	UserID int64  `json:"user_id"`
+	Reason string `json:"reason,omitempty"`
+	// Until is the end of the ban, nil for a permanent ban
+	Until    *time.Time `json:"until,omitempty"`
+	BannedAt time.Time  `json:"banned_at"`
+}
+
+// IsActive reports whether the ban is still in effect at the given time
+func (b Ban) IsActive(now time.Time) bool {
+	return b.Until == nil || now.Before(*b.Until)
+}
@@ -5,13 +5,99 @@ import (
 	"time"
 )
 
+// TombstoneBody replaces the body of a deleted comment that is still shown because of its replies
+const TombstoneBody = "comment removed"
+
+// HiddenBody replaces the body of a comment hidden by a moderator
+const HiddenBody = "comment hidden by a moderator"
+
+// MaxDepth is the deepest nesting level, replies to comments at this level are added to the parent's thread instead
+const MaxDepth = 5
+
 type Comment struct {
-	ID        string    `json:"id"`
-	PostID    string    `json:"post_id"`
-	User      User      `json:"user"`
-	Body      string    `json:"body"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID     string `json:"id"`
+	PostID string `json:"post_id"`
+	// ParentID is the id of the comment this one replies to, empty for top-level comments
+	ParentID string `json:"parent_id,omitempty"`
+	// Depth is the nesting level of the comment, 0 for top-level comments
+	Depth      int32  `json:"depth"`
+	ReplyCount int32  `json:"reply_count"`
+	User       User   `json:"user"`
+	Body       string `json:"body"`
+	// Edited is set once the body was changed, previous bodies are kept as revisions
+	Edited bool `json:"edited"`
+	// Reactions holds the number of reactions per emoji
+	Reactions map[string]int32 `json:"reactions"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	// DeletedAt is set when the comment is soft deleted, the comment is purged after the retention period
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// DeletedBy is the id of the user who deleted the comment
+	DeletedBy int64 `json:"deleted_by,omitempty"`
+	// HiddenAt is set when a moderator hides the comment, hidden comments keep their place but not their content
+	HiddenAt *time.Time `json:"hidden_at,omitempty"`
+	// HiddenBy is the id of the moderator who hid the comment
+	HiddenBy int64 `json:"hidden_by,omitempty"`
+	// Moderation is the verdict of the moderation pipeline for the current body
+	Moderation Moderation `json:"moderation"`
+	// Mentions are the ids of the users mentioned in the body, the author is never listed
+	Mentions []int64 `json:"mentions,omitempty"`
+}
+
+func (c Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+func (c Comment) IsHidden() bool {
+	return c.HiddenAt != nil
+}
+
+// IsPending reports whether the comment waits for a moderator to review it
+func (c Comment) IsPending() bool {
+	return c.Moderation.Status == ModerationPending
+}
+
+// ChangedAt returns the time of the latest change of the comment: creation, edit, deletion or moderation
+func (c Comment) ChangedAt() time.Time {
+	changedAt := c.UpdatedAt
+	if c.CreatedAt.After(changedAt) {
+		changedAt = c.CreatedAt
+	}
+	if c.DeletedAt != nil && c.DeletedAt.After(changedAt) {
+		changedAt = *c.DeletedAt
+	}
+	if c.HiddenAt != nil && c.HiddenAt.After(changedAt) {
+		changedAt = *c.HiddenAt
+	}
+	return changedAt
+}
+
+// Masked returns the comment with the body replaced by the moderation placeholder,
+// the mentions and moderation reasons are dropped as they tell about the hidden body
+func (c Comment) Masked() Comment {
+	c.Body = HiddenBody
+	c.Mentions = nil
+	c.Moderation.Reasons = nil
+	return c
+}
+
+// Pending returns the comment with the body replaced by the review placeholder,
+// the mentions and moderation reasons are dropped as they tell about the held body
+func (c Comment) Pending() Comment {
+	c.Body = PendingBody
+	c.Mentions = nil
+	c.Moderation.Reasons = nil
+	return c
+}
+
+// Tombstone returns the placeholder of the deleted comment, it keeps the thread position but hides the content
+func (c Comment) Tombstone() Comment {
+	c.Body = TombstoneBody
+	c.User = User{}
+	c.Reactions = nil
+	c.Mentions = nil
+	c.Moderation.Reasons = nil
+	return c
 }
 
 func NewID() string {
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComment_Placeholders(t *testing.T) {
+	comment := Comment{
+		ID:         "1",
+		User:       User{ID: 2},
+		Body:       "hello @id:3",
+		Reactions:  map[string]int32{"👍": 1},
+		Moderation: Moderation{Status: ModerationPending, Reasons: []string{"too many links"}},
+		Mentions:   []int64{3},
+	}
+
+	tests := []struct {
+		name         string
+		placeholder  Comment
+		expectedBody string
+	}{
+		{name: "masked", placeholder: comment.Masked(), expectedBody: HiddenBody},
+		{name: "pending", placeholder: comment.Pending(), expectedBody: PendingBody},
+		{name: "tombstone", placeholder: comment.Tombstone(), expectedBody: TombstoneBody},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedBody, tt.placeholder.Body)
+			assert.Empty(t, tt.placeholder.Mentions)
+			assert.Empty(t, tt.placeholder.Moderation.Reasons)
+			assert.Equal(t, ModerationPending, tt.placeholder.Moderation.Status)
+		})
+	}
+
+	// the placeholders are copies, the comment keeps its content
+	assert.Equal(t, []int64{3}, comment.Mentions)
+	assert.Equal(t, []string{"too many links"}, comment.Moderation.Reasons)
+}
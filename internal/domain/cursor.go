@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CursorDirection tells on which side of the cursor position the page is
+type CursorDirection string
+
+const (
+	CursorNext CursorDirection = "n"
+	CursorPrev CursorDirection = "p"
+)
+
+// Cursor is the position of a comment in the listing, used for keyset pagination.
+// Clients only see it encoded, it must be passed back as is.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+	Direction CursorDirection
+}
+
+// Encode returns the opaque representation of the cursor
+func (c Cursor) Encode() string {
+	raw := fmt.Sprintf("%d:%s:%s", c.CreatedAt.UnixNano(), c.ID, c.Direction)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses the cursor returned by Cursor.Encode
+func DecodeCursor(encoded string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if !primitive.IsValidObjectID(parts[1]) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	direction := CursorDirection(parts[2])
+	if direction != CursorNext && direction != CursorPrev {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{
+		CreatedAt: time.Unix(0, nanos).UTC(),
+		ID:        parts[1],
+		Direction: direction,
+	}, nil
+}
+
+// SyncCursor is the position in the changes of a post, ordered by the change time and the comment id.
+// A cursor without ID starts at ChangedAt, with ID it resumes after that comment. Clients only see it encoded.
+type SyncCursor struct {
+	ChangedAt time.Time
+	ID        string
+}
+
+// Encode returns the opaque representation of the cursor
+func (c SyncCursor) Encode() string {
+	raw := fmt.Sprintf("%d:%s", c.ChangedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeSyncCursor parses the cursor returned by SyncCursor.Encode
+func DecodeSyncCursor(encoded string) (SyncCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return SyncCursor{}, ErrInvalidCursor
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 2 {
+		return SyncCursor{}, ErrInvalidCursor
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return SyncCursor{}, ErrInvalidCursor
+	}
+
+	if !primitive.IsValidObjectID(parts[1]) {
+		return SyncCursor{}, ErrInvalidCursor
+	}
+
+	return SyncCursor{
+		ChangedAt: time.Unix(0, nanos).UTC(),
+		ID:        parts[1],
+	}, nil
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	cursor := Cursor{
+		CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 123000000, time.UTC),
+		ID:        NewID(),
+		Direction: CursorPrev,
+	}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+}
+
+func TestDecodeCursor_FailPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{
+			name:    "not base64",
+			encoded: "%%%",
+		},
+		{
+			name:    "missing parts",
+			encoded: Cursor{ID: NewID()}.Encode()[:4],
+		},
+		{
+			name:    "invalid id",
+			encoded: Cursor{ID: "not-an-id", Direction: CursorNext}.Encode(),
+		},
+		{
+			name:    "invalid direction",
+			encoded: Cursor{ID: NewID(), Direction: "x"}.Encode(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeCursor(tt.encoded)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestSyncCursor_EncodeDecode(t *testing.T) {
+	cursor := SyncCursor{
+		ChangedAt: time.Date(2024, 5, 1, 12, 30, 0, 123000000, time.UTC),
+		ID:        NewID(),
+	}
+
+	decoded, err := DecodeSyncCursor(cursor.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+}
+
+func TestDecodeSyncCursor_FailPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{
+			name:    "not base64",
+			encoded: "%%%",
+		},
+		{
+			name:    "pagination cursor",
+			encoded: Cursor{ID: NewID(), Direction: CursorNext}.Encode(),
+		},
+		{
+			name:    "invalid id",
+			encoded: SyncCursor{ID: "not-an-id"}.Encode(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeSyncCursor(tt.encoded)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestWithCursor(t *testing.T) {
+	t.Run("empty cursor keeps page pagination", func(t *testing.T) {
+		filter, err := NewFilter(WithCursor(""))
+		assert.NoError(t, err)
+		assert.False(t, filter.HasCursor())
+	})
+
+	t.Run("valid cursor", func(t *testing.T) {
+		cursor := Cursor{CreatedAt: time.Now().UTC(), ID: NewID(), Direction: CursorNext}
+
+		filter, err := NewFilter(WithCursor(cursor.Encode()))
+		assert.NoError(t, err)
+		assert.True(t, filter.HasCursor())
+		assert.Equal(t, cursor.ID, filter.Cursor.ID)
+		assert.True(t, filter.FilterMap["cursor"])
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := NewFilter(WithCursor("invalid"))
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
@@ -3,22 +3,50 @@ package domain
 import "errors"
 
 var (
-	ErrInvalidID    = errors.New("invalid id")
-	ErrInvalidArg   = errors.New("invalid argument")
-	ErrInternal     = errors.New("internal error")
-	ErrUnauthorized = errors.New("unauthorized")
+	ErrInvalidID     = errors.New("invalid id")
+	ErrInvalidArg    = errors.New("invalid argument")
+	ErrInternal      = errors.New("internal error")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrTooManyRequests is returned when the rate limit of the action is exceeded
+	ErrTooManyRequests = errors.New("too many requests")
 
 	ErrTokenIsNotValid         = errors.New("token is not valid")
 	ErrInvalidTokenClaims      = errors.New("invalid token claims")
 	ErrUserIDClaimNotFound     = errors.New("user_id claim not found or invalid")
 	ErrTokenIsExpired          = errors.New("token is expired")
 	ErrUnexpectedSigningMethod = errors.New("unexpected signing method")
+	ErrTokenNotValidYet        = errors.New("token is not valid yet")
+	ErrTokenInvalidIssuer      = errors.New("token has invalid issuer")
+	ErrTokenInvalidAudience    = errors.New("token has invalid audience")
 )
 
 var (
 	ErrUserNotFound = errors.New("user not found")
+	// ErrUserBanned is returned when a banned user tries to change comments
+	ErrUserBanned = errors.New("user is banned")
 )
 
 var (
-	ErrCommentNotFound = errors.New("comment not found")
+	ErrCommentNotFound    = errors.New("comment not found")
+	ErrParentPostMismatch = errors.New("parent comment belongs to another post")
+	ErrParentUnavailable  = errors.New("parent comment is deleted, hidden or awaiting review")
+	ErrInvalidReaction    = errors.New("invalid reaction")
+	ErrCommentNotDeleted  = errors.New("comment is not deleted")
+	ErrCommentNotPending  = errors.New("comment is not awaiting review")
+)
+
+var (
+	ErrReportNotFound      = errors.New("report not found")
+	ErrAlreadyReported     = errors.New("comment is already reported by the user")
+	ErrOwnCommentReport    = errors.New("users can not report their own comments")
+	ErrReportResolved      = errors.New("report is already resolved")
+	ErrInvalidReportAction = errors.New("invalid report action")
+	// ErrOutboxEmpty is returned when no outbox message is due to be relayed
+	ErrOutboxEmpty = errors.New("no pending outbox messages")
+)
+
+var (
+	ErrPostNotFound = errors.New("post not found")
+	ErrClubNotFound = errors.New("club not found")
 )
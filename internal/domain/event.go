@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+type CommentEventType string
+
+const (
+	CommentCreated CommentEventType = "comment.created"
+	// CommentUpdated is emitted when the body, visibility or deletion mark of the comment changes
+	CommentUpdated          CommentEventType = "comment.updated"
+	CommentDeleted          CommentEventType = "comment.deleted"
+	CommentReactionsUpdated CommentEventType = "comment.reactions_updated"
+	// CommentMentioned is emitted when a created or edited comment mentions users that were not mentioned before
+	CommentMentioned CommentEventType = "comment.mentioned"
+)
+
+// CommentEvent describes a change of a comment, it is emitted after the change is stored
+type CommentEvent struct {
+	// ID is unique per event, it lets the consumers of the message broker drop redelivered events
+	ID   string
+	Type CommentEventType
+	// Comment is the comment after the change as it is shown to users, deleted and hidden comments are masked
+	Comment Comment
+	// ActorID is the id of the user who made the change
+	ActorID int64
+	// Mentioned are the ids of the users to notify, only set for CommentMentioned
+	Mentioned  []int64
+	OccurredAt time.Time
+}
+
+func NewCommentEvent(eventType CommentEventType, comment Comment, actorID int64) CommentEvent {
+	return CommentEvent{
+		ID:         NewID(),
+		Type:       eventType,
+		Comment:    comment,
+		ActorID:    actorID,
+		OccurredAt: time.Now(),
+	}
+}
+
+// NewMentionEvent returns the CommentMentioned event notifying the mentioned users about the comment
+func NewMentionEvent(comment Comment, actorID int64, mentioned []int64) CommentEvent {
+	event := NewCommentEvent(CommentMentioned, comment, actorID)
+	event.Mentioned = mentioned
+	return event
+}
@@ -31,6 +31,8 @@ type Filter struct {
 	PageSize  int32
 	SortBy    SortBy
 	SortOrder SortOrder
+	// Cursor switches the listing to keyset pagination, Page is ignored and results are sorted by creation time
+	Cursor    *Cursor
 	FilterMap map[string]bool
 }
 
@@ -41,6 +43,11 @@ func (f Filter) Offset() int32 {
 	return (f.Page - 1) * f.PageSize
 }
 
+// HasCursor reports whether the filter uses keyset pagination instead of page/offset
+func (f Filter) HasCursor() bool {
+	return f.Cursor != nil
+}
+
 func NewFilter(cfgs ...FilterConfiguration) (*Filter, error) {
 	filter := &Filter{
 		Page:      1,
@@ -102,3 +109,21 @@ func WithSortOrder(sortOrder SortOrder) FilterConfiguration {
 		return nil
 	}
 }
+
+// WithCursor sets the encoded cursor returned in the pagination metadata, an empty cursor keeps page/offset pagination
+func WithCursor(cursor string) FilterConfiguration {
+	return func(filter *Filter) error {
+		if cursor == "" {
+			return nil
+		}
+
+		decoded, err := DecodeCursor(cursor)
+		if err != nil {
+			return err
+		}
+
+		filter.Cursor = &decoded
+		filter.FilterMap["cursor"] = true
+		return nil
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxMentions is how many distinct mentions of a body are resolved, the following ones are ignored
+const MaxMentions = 10
+
+// mentionPattern matches @id:<user_id> and @<first name> <last name>, the @ must not follow a letter or a digit,
+// so e-mail addresses are not mentions, and a bare number like @2024 is not a mention
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@])@(?:id:(\d+)\b|(\p{L}+(?:['-]\p{L}+)*) (\p{L}+(?:['-]\p{L}+)*))`)
+
+// Mention is a reference to a user in a comment body, either by id or by full name
+type Mention struct {
+	UserID    int64
+	FirstName string
+	LastName  string
+}
+
+// ByID reports whether the user is mentioned by id
+func (m Mention) ByID() bool {
+	return m.UserID != 0
+}
+
+// ParseMentions returns the distinct mentions of the body in order of appearance, at most MaxMentions
+func ParseMentions(body string) []Mention {
+	var mentions []Mention
+	seen := make(map[Mention]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		var mention Mention
+		if match[1] != "" {
+			userID, err := strconv.ParseInt(match[1], 10, 64)
+			if err != nil || userID <= 0 {
+				continue
+			}
+			mention.UserID = userID
+		} else {
+			mention.FirstName = match[2]
+			mention.LastName = match[3]
+		}
+
+		key := Mention{UserID: mention.UserID, FirstName: strings.ToLower(mention.FirstName), LastName: strings.ToLower(mention.LastName)}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		mentions = append(mentions, mention)
+		if len(mentions) == MaxMentions {
+			break
+		}
+	}
+
+	return mentions
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []Mention
+	}{
+		{name: "no mentions", body: "hello there", want: nil},
+		{name: "user id", body: "@id:42 look at this", want: []Mention{{UserID: 42}}},
+		{name: "full name", body: "thanks @Ivan Petrov!", want: []Mention{{FirstName: "Ivan", LastName: "Petrov"}}},
+		{name: "unicode name", body: "@Әлия Нұрлан", want: []Mention{{FirstName: "Әлия", LastName: "Нұрлан"}}},
+		{name: "hyphenated name", body: "@Anna Smith-Jones", want: []Mention{{FirstName: "Anna", LastName: "Smith-Jones"}}},
+		{
+			name: "several mentions",
+			body: "@id:1, @id:2 and @Anna Smith",
+			want: []Mention{{UserID: 1}, {UserID: 2}, {FirstName: "Anna", LastName: "Smith"}},
+		},
+		{name: "duplicates", body: "@id:1 @id:1 @anna smith @Anna Smith", want: []Mention{{UserID: 1}, {FirstName: "anna", LastName: "smith"}}},
+		{name: "email", body: "write to john@example.com", want: nil},
+		{name: "id followed by letters", body: "@id:123abc", want: nil},
+		{name: "single word", body: "@Ivan", want: nil},
+		{name: "zero id", body: "@id:0", want: nil},
+		{name: "bare number", body: "see you in @2024", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseMentions(tt.body))
+		})
+	}
+}
+
+func TestParseMentions_Limit(t *testing.T) {
+	var body strings.Builder
+	for i := 1; i <= MaxMentions+5; i++ {
+		fmt.Fprintf(&body, "@id:%d ", i)
+	}
+
+	mentions := ParseMentions(body.String())
+	assert.Len(t, mentions, MaxMentions)
+	assert.Equal(t, int64(MaxMentions), mentions[MaxMentions-1].UserID)
+}
@@ -4,11 +4,15 @@ import "math"
 
 // PaginationMetadata represents the metadata for paginated responses.
 type PaginationMetadata struct {
-	CurrentPage  int32
-	PageSize     int32
-	FirstPage    int32
-	LastPage     int32
-	TotalRecords int32
+	CurrentPage  int32 `json:"current_page"`
+	PageSize     int32 `json:"page_size"`
+	FirstPage    int32 `json:"first_page"`
+	LastPage     int32 `json:"last_page"`
+	TotalRecords int32 `json:"total_records"`
+	// NextCursor and PrevCursor are set when there are more comments in that direction,
+	// with keyset pagination only them and PageSize are filled
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // CalculatePaginationMetadata calculates the pagination metadata based on the total number of records, the current page, and the page size.
@@ -0,0 +1,67 @@
+package domain
+
+import "errors"
+
+// PendingBody replaces the body of a comment that waits for a moderator to review it
+const PendingBody = "comment is awaiting review"
+
+// ErrCommentRejected is returned when the moderation pipeline rejects the body of a comment
+var ErrCommentRejected = errors.New("comment rejected by moderation")
+
+// CommentRejectedError holds the reason the moderation pipeline rejected the comment
+type CommentRejectedError struct {
+	Reason string
+}
+
+func (e CommentRejectedError) Error() string {
+	return ErrCommentRejected.Error() + ": " + e.Reason
+}
+
+func (e CommentRejectedError) Unwrap() error {
+	return ErrCommentRejected
+}
+
+// ModerationStatus is the verdict of the moderation pipeline, the statuses are ordered by severity
+type ModerationStatus string
+
+const (
+	ModerationApproved ModerationStatus = "approved"
+	// ModerationMasked comments are stored with the offending parts of the body masked
+	ModerationMasked ModerationStatus = "masked"
+	// ModerationPending comments are shown with a placeholder until a moderator reviews them
+	ModerationPending ModerationStatus = "pending"
+	// ModerationRejected comments are not stored
+	ModerationRejected ModerationStatus = "rejected"
+)
+
+var moderationSeverity = map[ModerationStatus]int{
+	ModerationApproved: 0,
+	ModerationMasked:   1,
+	ModerationPending:  2,
+	ModerationRejected: 3,
+}
+
+// Severer reports whether the status is more severe than the other one
+func (s ModerationStatus) Severer(other ModerationStatus) bool {
+	return moderationSeverity[s] > moderationSeverity[other]
+}
+
+// Moderation is the verdict of the moderation pipeline stored on the comment
+type Moderation struct {
+	Status ModerationStatus `json:"status"`
+	// Reasons are the checks that did not approve the body
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// ModerationResult is the outcome of a single moderation check
+type ModerationResult struct {
+	Status ModerationStatus
+	// Body is the masked body when the status is masked
+	Body   string
+	Reason string
+}
+
+// Approve is the result of a check that found nothing
+func Approve() ModerationResult {
+	return ModerationResult{Status: ModerationApproved}
+}
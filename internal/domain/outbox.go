@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending"
+	OutboxSent    OutboxStatus = "sent"
+)
+
+// OutboxMessage is a message for the message broker stored in the same transaction as the change it describes,
+// it is relayed to the broker until the broker accepts it
+type OutboxMessage struct {
+	// ID is the id of the event, it stays the same on every attempt
+	ID         string
+	Exchange   string
+	RoutingKey string
+	// Payload is the JSON body of the message
+	Payload []byte
+	Status  OutboxStatus
+	// Attempts is the number of failed attempts to publish the message
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+	// NextAttemptAt is when the message is due to be published, it is moved forward while a relay publishes it
+	// and after every failed attempt
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+}
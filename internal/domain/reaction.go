@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxReactionRunes bounds the length of a reaction, enough for emoji built from several code points
+const maxReactionRunes = 8
+
+type Reaction struct {
+	CommentID string    `json:"comment_id"`
+	UserID    int64     `json:"user_id"`
+	Emoji     string    `json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ValidateReaction checks that the reaction is a short non-ASCII sequence, i.e. an emoji.
+// ASCII is rejected so a reaction can be used as a key in the stored counters.
+func ValidateReaction(emoji string) error {
+	if emoji == "" || !utf8.ValidString(emoji) || utf8.RuneCountInString(emoji) > maxReactionRunes {
+		return ErrInvalidReaction
+	}
+
+	for _, r := range emoji {
+		if r <= unicode.MaxASCII {
+			return ErrInvalidReaction
+		}
+	}
+
+	return nil
+}
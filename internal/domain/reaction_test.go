@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReaction(t *testing.T) {
+	tests := []struct {
+		name    string
+		emoji   string
+		wantErr error
+	}{
+		{name: "emoji", emoji: "👍", wantErr: nil},
+		{name: "emoji with variation selector", emoji: "❤️", wantErr: nil},
+		{name: "emoji sequence", emoji: "👩‍💻", wantErr: nil},
+		{name: "empty", emoji: "", wantErr: ErrInvalidReaction},
+		{name: "ascii", emoji: "+1", wantErr: ErrInvalidReaction},
+		{name: "field path", emoji: "👍.count", wantErr: ErrInvalidReaction},
+		{name: "too long", emoji: "👍👍👍👍👍👍👍👍👍", wantErr: ErrInvalidReaction},
+		{name: "invalid utf8", emoji: "\xff", wantErr: ErrInvalidReaction},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReaction(tt.emoji)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
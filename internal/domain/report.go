@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+type ReportStatus string
+
+const (
+	ReportOpen      ReportStatus = "open"
+	ReportDismissed ReportStatus = "dismissed"
+	// ReportHidden and ReportDeleted tell the comment was hidden or deleted because of the report
+	ReportHidden  ReportStatus = "hidden"
+	ReportDeleted ReportStatus = "deleted"
+)
+
+// ReportAction is the decision of a moderator on the open reports of a comment
+type ReportAction string
+
+const (
+	ReportActionDismiss ReportAction = "dismiss"
+	ReportActionHide    ReportAction = "hide"
+	ReportActionDelete  ReportAction = "delete"
+)
+
+// ParseReportAction returns the action of the name, ErrInvalidReportAction for unknown names
+func ParseReportAction(name string) (ReportAction, error) {
+	switch action := ReportAction(name); action {
+	case ReportActionDismiss, ReportActionHide, ReportActionDelete:
+		return action, nil
+	default:
+		return "", ErrInvalidReportAction
+	}
+}
+
+// Status returns the status of the reports resolved by the action
+func (a ReportAction) Status() ReportStatus {
+	switch a {
+	case ReportActionHide:
+		return ReportHidden
+	case ReportActionDelete:
+		return ReportDeleted
+	default:
+		return ReportDismissed
+	}
+}
+
+// Report is a complaint of a user about a comment, a user has at most one open report per comment
+type Report struct {
+	ID        string `json:"id"`
+	CommentID string `json:"comment_id"`
+	PostID    string `json:"post_id"`
+	// ClubID is the club that owns the post, its admins review the report
+	ClubID     int64        `json:"club_id"`
+	ReporterID int64        `json:"reporter_id"`
+	Reason     string       `json:"reason,omitempty"`
+	Status     ReportStatus `json:"status"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ResolvedAt *time.Time   `json:"resolved_at,omitempty"`
+	// ResolvedBy is the id of the moderator who resolved the report
+	ResolvedBy int64 `json:"resolved_by,omitempty"`
+}
+
+func (r Report) IsOpen() bool {
+	return r.Status == ReportOpen
+}
+
+// ReportFilter selects the open reports of a club or of a post
+type ReportFilter struct {
+	ClubID   int64
+	PostID   string
+	Page     int32
+	PageSize int32
+}
+
+func (f ReportFilter) Limit() int32 {
+	return f.PageSize
+}
+
+func (f ReportFilter) Offset() int32 {
+	return (f.Page - 1) * f.PageSize
+}
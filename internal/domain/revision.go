@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// Revision is a previous version of an edited comment
+type Revision struct {
+	ID        string    `json:"id"`
+	CommentID string    `json:"comment_id"`
+	Body      string    `json:"body"`
+	EditorID  int64     `json:"editor_id"`
+	EditedAt  time.Time `json:"edited_at"`
+}
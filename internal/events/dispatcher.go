@@ -0,0 +1,58 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+)
+
+// Subscriber handles the comment events passed to the dispatcher
+//
+//go:generate mockery --name Subscriber
+type Subscriber interface {
+	HandleCommentEvent(ctx context.Context, event domain.CommentEvent) error
+}
+
+// Dispatcher fans the comment events out to all subscribers.
+// Subscribers are called one after another, a failing subscriber does not stop the others.
+type Dispatcher struct {
+	log         *slog.Logger
+	mu          sync.RWMutex
+	subscribers []Subscriber
+}
+
+func NewDispatcher(log *slog.Logger) *Dispatcher {
+	return &Dispatcher{log: log}
+}
+
+// Subscribe adds the subscriber, it receives the events published after it was added
+func (d *Dispatcher) Subscribe(subscriber Subscriber) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.subscribers = append(d.subscribers, subscriber)
+}
+
+func (d *Dispatcher) PublishCommentEvent(ctx context.Context, event domain.CommentEvent) {
+	const op = "events.dispatcher.publish_comment_event"
+	log := d.log.With(slog.String("op", op))
+
+	d.mu.RLock()
+	subscribers := d.subscribers
+	d.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		err := subscriber.HandleCommentEvent(ctx, event)
+		if err != nil {
+			log.Error(
+				"subscriber failed to handle comment event",
+				slog.String("type", string(event.Type)),
+				slog.String("comment_id", event.Comment.ID),
+				logger.Err(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/events/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDispatcher_PublishCommentEvent(t *testing.T) {
+	first := mocks.NewSubscriber(t)
+	second := mocks.NewSubscriber(t)
+
+	dispatcher := NewDispatcher(logger.Plug())
+	dispatcher.Subscribe(first)
+	dispatcher.Subscribe(second)
+
+	event := domain.NewCommentEvent(domain.CommentCreated, domain.Comment{ID: "1"}, 1)
+
+	// the failing subscriber must not stop the next one
+	first.On("HandleCommentEvent", mock.Anything, event).Return(assert.AnError).Once()
+	second.On("HandleCommentEvent", mock.Anything, event).Return(nil).Once()
+
+	dispatcher.PublishCommentEvent(context.Background(), event)
+}
@@ -0,0 +1,82 @@
+package events
+
+import (
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/rabbitmq"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/commentevents"
+)
+
+// routingKeys maps the comment events other services are interested in to the routing keys of their messages,
+// reactions are published as updates since the message carries the reaction counts
+var routingKeys = map[domain.CommentEventType]string{
+	domain.CommentCreated:          rabbitmq.CommentCreatedEventRoutingKey,
+	domain.CommentUpdated:          rabbitmq.CommentUpdatedEventRoutingKey,
+	domain.CommentReactionsUpdated: rabbitmq.CommentUpdatedEventRoutingKey,
+	domain.CommentDeleted:          rabbitmq.CommentDeletedEventRoutingKey,
+	domain.CommentMentioned:        rabbitmq.CommentMentionedEventRoutingKey,
+}
+
+// NewMessage returns the message of the event for the message broker, its type is the routing key.
+// The returned bool is false for the events that are not sent to the broker.
+func NewMessage(event domain.CommentEvent) (commentevents.Envelope, bool, error) {
+	routingKey, ok := routingKeys[event.Type]
+	if !ok {
+		return commentevents.Envelope{}, false, nil
+	}
+
+	var data any
+	switch event.Type {
+	case domain.CommentDeleted:
+		data = deletedToMessage(event.Comment)
+	case domain.CommentMentioned:
+		data = commentevents.MentionedV1{
+			Comment:          commentToMessage(event.Comment),
+			MentionedUserIDs: event.Mentioned,
+		}
+	default:
+		data = commentToMessage(event.Comment)
+	}
+
+	envelope, err := commentevents.NewEnvelope(event.ID, routingKey, event.ActorID, event.OccurredAt, data)
+	if err != nil {
+		return commentevents.Envelope{}, false, err
+	}
+
+	return envelope, true, nil
+}
+
+func commentToMessage(comment domain.Comment) commentevents.CommentV1 {
+	return commentevents.CommentV1{
+		ID:       comment.ID,
+		PostID:   comment.PostID,
+		ParentID: comment.ParentID,
+		Depth:    comment.Depth,
+		Author: commentevents.UserV1{
+			ID:        comment.User.ID,
+			FirstName: comment.User.FirstName,
+			LastName:  comment.User.LastName,
+			AvatarURL: comment.User.AvatarURL,
+		},
+		Body:             comment.Body,
+		Edited:           comment.Edited,
+		Hidden:           comment.IsHidden(),
+		ModerationStatus: string(comment.Moderation.Status),
+		Reactions:        comment.Reactions,
+		Mentions:         comment.Mentions,
+		CreatedAt:        comment.CreatedAt,
+		UpdatedAt:        comment.UpdatedAt,
+	}
+}
+
+func deletedToMessage(comment domain.Comment) commentevents.DeletedV1 {
+	message := commentevents.DeletedV1{
+		ID:        comment.ID,
+		PostID:    comment.PostID,
+		ParentID:  comment.ParentID,
+		DeletedBy: comment.DeletedBy,
+	}
+	if comment.DeletedAt != nil {
+		message.DeletedAt = *comment.DeletedAt
+	}
+	return message
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/commentevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessage(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	comment := domain.Comment{
+		ID:         "c1",
+		PostID:     "p1",
+		ParentID:   "c0",
+		Depth:      1,
+		User:       domain.User{ID: 1, FirstName: "Aru", LastName: "Man"},
+		Body:       "@id:2 hi",
+		Reactions:  map[string]int32{"👍": 2},
+		Moderation: domain.Moderation{Status: domain.ModerationApproved},
+		Mentions:   []int64{2},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	commentMessage := commentevents.CommentV1{
+		ID:               "c1",
+		PostID:           "p1",
+		ParentID:         "c0",
+		Depth:            1,
+		Author:           commentevents.UserV1{ID: 1, FirstName: "Aru", LastName: "Man"},
+		Body:             "@id:2 hi",
+		ModerationStatus: "approved",
+		Reactions:        map[string]int32{"👍": 2},
+		Mentions:         []int64{2},
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	deleted := comment.Tombstone()
+	deleted.DeletedAt = &now
+	deleted.DeletedBy = 3
+
+	tests := []struct {
+		name     string
+		event    domain.CommentEvent
+		wantType string
+		wantData any
+	}{
+		{
+			name:     "created",
+			event:    domain.NewCommentEvent(domain.CommentCreated, comment, 1),
+			wantType: commentevents.TypeCreated,
+			wantData: commentMessage,
+		},
+		{
+			name:     "updated",
+			event:    domain.NewCommentEvent(domain.CommentUpdated, comment, 1),
+			wantType: commentevents.TypeUpdated,
+			wantData: commentMessage,
+		},
+		{
+			name:     "reactions are updates",
+			event:    domain.NewCommentEvent(domain.CommentReactionsUpdated, comment, 2),
+			wantType: commentevents.TypeUpdated,
+			wantData: commentMessage,
+		},
+		{
+			name:     "deleted",
+			event:    domain.NewCommentEvent(domain.CommentDeleted, deleted, 3),
+			wantType: commentevents.TypeDeleted,
+			wantData: commentevents.DeletedV1{ID: "c1", PostID: "p1", ParentID: "c0", DeletedBy: 3, DeletedAt: now},
+		},
+		{
+			name:     "mentioned",
+			event:    domain.NewMentionEvent(comment, 1, []int64{2}),
+			wantType: commentevents.TypeMentioned,
+			wantData: commentevents.MentionedV1{Comment: commentMessage, MentionedUserIDs: []int64{2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, ok, err := NewMessage(tt.event)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			assert.Equal(t, tt.event.ID, message.ID)
+			assert.Equal(t, tt.wantType, message.Type)
+			assert.Equal(t, commentevents.Version, message.Version)
+			assert.Equal(t, tt.event.ActorID, message.ActorID)
+			assert.Equal(t, tt.event.OccurredAt, message.OccurredAt)
+
+			wantData, err := json.Marshal(tt.wantData)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(wantData), string(message.Data))
+		})
+	}
+}
+
+func TestNewMessage_NotForwarded(t *testing.T) {
+	_, ok, err := NewMessage(domain.NewCommentEvent("comment.unknown", domain.Comment{ID: "c1"}, 1))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,55 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OutboxStorage is an autogenerated mock type for the OutboxStorage type
+type OutboxStorage struct {
+	mock.Mock
+}
+
+// AddOutboxMessages provides a mock function with given fields: ctx, messages
+func (_m *OutboxStorage) AddOutboxMessages(ctx context.Context, messages ...domain.OutboxMessage) error {
+	_va := make([]interface{}, len(messages))
+	for _i := range messages {
+		_va[_i] = messages[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOutboxMessages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...domain.OutboxMessage) error); ok {
+		r0 = rf(ctx, messages...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOutboxStorage creates a new instance of OutboxStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxStorage {
+	mock := &OutboxStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
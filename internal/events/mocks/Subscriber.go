@@ -0,0 +1,48 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Subscriber is an autogenerated mock type for the Subscriber type
+type Subscriber struct {
+	mock.Mock
+}
+
+// HandleCommentEvent provides a mock function with given fields: ctx, event
+func (_m *Subscriber) HandleCommentEvent(ctx context.Context, event domain.CommentEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleCommentEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CommentEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSubscriber creates a new instance of Subscriber. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSubscriber(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Subscriber {
+	mock := &Subscriber{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/rabbitmq"
+)
+
+// OutboxStorage stores the messages to relay to the message broker, it is implemented by mongodb.Storage
+//
+//go:generate mockery --name OutboxStorage
+type OutboxStorage interface {
+	AddOutboxMessages(ctx context.Context, messages ...domain.OutboxMessage) error
+}
+
+// Outbox turns the comment events other services are interested in into the messages of the comment exchange
+// and stores them, the relay publishes them later
+type Outbox struct {
+	storage OutboxStorage
+}
+
+func NewOutbox(storage OutboxStorage) *Outbox {
+	return &Outbox{storage: storage}
+}
+
+// AddCommentEvents stores the messages of the events, pass the context of the transaction of the change
+func (o *Outbox) AddCommentEvents(ctx context.Context, events ...domain.CommentEvent) error {
+	const op = "events.outbox.add_comment_events"
+
+	now := time.Now()
+	messages := make([]domain.OutboxMessage, 0, len(events))
+	for _, event := range events {
+		message, ok, err := NewMessage(event)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if !ok {
+			continue
+		}
+
+		payload, err := json.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		messages = append(messages, domain.OutboxMessage{
+			ID:            event.ID,
+			Exchange:      rabbitmq.CommentExchangeName,
+			RoutingKey:    message.Type,
+			Payload:       payload,
+			Status:        domain.OutboxPending,
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		})
+	}
+
+	err := o.storage.AddOutboxMessages(ctx, messages...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
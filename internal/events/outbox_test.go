@@ -0,0 +1,50 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/events/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/rabbitmq"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/commentevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutbox_AddCommentEvents(t *testing.T) {
+	storage := mocks.NewOutboxStorage(t)
+	outbox := NewOutbox(storage)
+
+	created := domain.NewCommentEvent(domain.CommentCreated, domain.Comment{ID: "c1", PostID: "p1"}, 1)
+	unknown := domain.NewCommentEvent("comment.unknown", domain.Comment{ID: "c1"}, 1)
+
+	storage.On("AddOutboxMessages", mock.Anything, mock.MatchedBy(func(message domain.OutboxMessage) bool {
+		var envelope commentevents.Envelope
+		err := json.Unmarshal(message.Payload, &envelope)
+		require.NoError(t, err)
+
+		return message.ID == created.ID &&
+			message.Exchange == rabbitmq.CommentExchangeName &&
+			message.RoutingKey == rabbitmq.CommentCreatedEventRoutingKey &&
+			message.Status == domain.OutboxPending &&
+			!message.NextAttemptAt.IsZero() &&
+			envelope.ID == created.ID &&
+			envelope.Type == commentevents.TypeCreated
+	})).Return(nil).Once()
+
+	err := outbox.AddCommentEvents(context.Background(), created, unknown)
+	assert.NoError(t, err)
+}
+
+func TestOutbox_AddCommentEvents_FailPath(t *testing.T) {
+	storage := mocks.NewOutboxStorage(t)
+	outbox := NewOutbox(storage)
+
+	storage.On("AddOutboxMessages", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	err := outbox.AddCommentEvents(context.Background(), domain.NewCommentEvent(domain.CommentDeleted, domain.Comment{ID: "c1"}, 1))
+	assert.ErrorIs(t, err, assert.AnError)
+}
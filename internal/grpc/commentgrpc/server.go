@@ -74,10 +74,15 @@ func (s *Server) ListPostComments(ctx context.Context, request *commentv1.ListPo
 
 func handleErr(err error) error {
 	switch {
-	case errors.Is(err, domain.ErrInvalidID), errors.Is(err, domain.ErrInvalidArg):
+	case errors.Is(err, domain.ErrInvalidID),
+		errors.Is(err, domain.ErrInvalidArg),
+		errors.Is(err, domain.ErrParentPostMismatch),
+		errors.Is(err, domain.ErrInvalidReaction):
 		return status.Error(codes.InvalidArgument, err.Error())
-	case errors.Is(err, domain.ErrCommentNotFound):
+	case errors.Is(err, domain.ErrCommentNotFound), errors.Is(err, domain.ErrPostNotFound):
 		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrCommentNotDeleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal error")
 	}
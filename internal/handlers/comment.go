@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+)
+
+// maxBodySize is the largest request body the comment routes accept
+const maxBodySize = 1 << 20
+
+var (
+	// errUnsupportedMediaType is returned for the request bodies that are not sent as application/json
+	errUnsupportedMediaType = errors.New("content type must be application/json")
+	// errBodyTooLarge is returned for the request bodies larger than maxBodySize
+	errBodyTooLarge = errors.New("request body is too large")
+)
+
+type commentResponse struct {
+	Comment domain.Comment `json:"comment"`
+}
+
+type commentsResponse struct {
+	Comments []domain.Comment          `json:"comments"`
+	Metadata domain.PaginationMetadata `json:"metadata"`
+}
+
+type commentBody struct {
+	ParentID string `json:"parent_id"`
+	Body     string `json:"body"`
+}
+
+// listPostComments lists the top-level comments of the post.
+// Query parameters: page, page_size, sort_by (created_at, updated_at), sort_order (asc, desc) and cursor.
+func (h *Handler) listPostComments(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.comment.list_post_comments"
+	log := h.log.With(slog.String("op", op))
+
+	user, _ := AuthUserFromContext(r.Context())
+
+	filter, err := filterFromQuery(r)
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	err = h.authorizePostView(r.Context(), user.ID, r.PathValue("post_id"))
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	comments, metadata, err := h.commentService.ListByPostID(r.Context(), r.PathValue("post_id"), filter)
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, commentsResponse{Comments: comments, Metadata: metadata})
+}
+
+// getComment returns the comment, the comments of the posts the user can not view are reported as not found
+// so the response does not reveal that the comment exists.
+func (h *Handler) getComment(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.comment.get_comment"
+	log := h.log.With(slog.String("op", op))
+
+	user, _ := AuthUserFromContext(r.Context())
+
+	comment, err := h.commentService.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	err = h.authorizePostView(r.Context(), user.ID, comment.PostID)
+	if errors.Is(err, domain.ErrUnauthorized) {
+		err = domain.ErrCommentNotFound
+	}
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, commentResponse{Comment: comment})
+}
+
+func (h *Handler) createComment(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.comment.create_comment"
+	log := h.log.With(slog.String("op", op))
+
+	user, _ := AuthUserFromContext(r.Context())
+
+	var input commentBody
+	err := decodeBody(r, &input)
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	err = h.authorizePostView(r.Context(), user.ID, r.PathValue("post_id"))
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	comment, err := h.commentService.Create(r.Context(), commentservice.CreateCommentDTO{
+		PostID:   r.PathValue("post_id"),
+		ParentID: input.ParentID,
+		Body:     input.Body,
+		UserID:   user.ID,
+	})
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, commentResponse{Comment: comment})
+}
+
+func (h *Handler) updateComment(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.comment.update_comment"
+	log := h.log.With(slog.String("op", op))
+
+	user, _ := AuthUserFromContext(r.Context())
+
+	var input commentBody
+	err := decodeBody(r, &input)
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	comment, err := h.commentService.Update(r.Context(), commentservice.UpdateCommentDTO{
+		CommentID: r.PathValue("id"),
+		Body:      input.Body,
+		UserID:    user.ID,
+	})
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, commentResponse{Comment: comment})
+}
+
+func (h *Handler) deleteComment(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers.comment.delete_comment"
+	log := h.log.With(slog.String("op", op))
+
+	user, _ := AuthUserFromContext(r.Context())
+
+	err := h.commentService.Delete(r.Context(), commentservice.DeleteCommentDTO{
+		CommentID: r.PathValue("id"),
+		UserID:    user.ID,
+	})
+	if err != nil {
+		handleErr(log, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizePostView returns domain.ErrUnauthorized unless the user may read the comments of the post
+func (h *Handler) authorizePostView(ctx context.Context, userID int64, postID string) error {
+	allowed, err := h.accessChecker.CanViewPost(ctx, userID, postID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return domain.ErrUnauthorized
+	}
+	return nil
+}
+
+// decodeBody decodes the json body of the request, the body must contain a non-empty comment body
+func decodeBody(r *http.Request, input *commentBody) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return errUnsupportedMediaType
+	}
+
+	err = json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodySize)).Decode(input)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return errBodyTooLarge
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+	if input.Body == "" {
+		return fmt.Errorf("%w: body is required", domain.ErrInvalidArg)
+	}
+	return nil
+}
+
+func filterFromQuery(r *http.Request) (domain.Filter, error) {
+	query := r.URL.Query()
+
+	page, err := queryInt32(query.Get("page"))
+	if err != nil {
+		return domain.Filter{}, fmt.Errorf("%w: page: %w", domain.ErrInvalidArg, err)
+	}
+	pageSize, err := queryInt32(query.Get("page_size"))
+	if err != nil {
+		return domain.Filter{}, fmt.Errorf("%w: page_size: %w", domain.ErrInvalidArg, err)
+	}
+
+	sortBy := domain.SortBy(query.Get("sort_by"))
+	switch sortBy {
+	case domain.SortByUnspecified, domain.SortByCreatedAt, domain.SortByUpdatedAt:
+	default:
+		return domain.Filter{}, fmt.Errorf("%w: sort_by must be one of created_at, updated_at", domain.ErrInvalidArg)
+	}
+
+	sortOrder := domain.SortOrder(query.Get("sort_order"))
+	switch sortOrder {
+	case "", domain.SortOrderAsc, domain.SortOrderDesc:
+	default:
+		return domain.Filter{}, fmt.Errorf("%w: sort_order must be one of asc, desc", domain.ErrInvalidArg)
+	}
+
+	configs := []domain.FilterConfiguration{
+		domain.WithPage(page),
+		domain.WithPageSize(pageSize),
+		domain.WithSortOrder(sortOrder),
+		domain.WithCursor(query.Get("cursor")),
+	}
+	if sortBy != domain.SortByUnspecified {
+		configs = append(configs, domain.WithSortBy(sortBy))
+	}
+
+	filter, err := domain.NewFilter(configs...)
+	if err != nil {
+		return domain.Filter{}, err
+	}
+
+	return *filter, nil
+}
+
+// queryInt32 parses the optional numeric query parameter, an empty value is 0
+func queryInt32(value string) (int32, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(n), nil
+}
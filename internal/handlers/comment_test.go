@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/handlers/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+	pkgjwt "github.com/ARUMANDESU/uniclubs-comments-service/pkg/jwt"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/origin"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+type Suite struct {
+	mockService *mocks.CommentService
+	mockAccess  *mocks.AccessChecker
+	handler     *Handler
+}
+
+const testOrigin = "https://app.example.com"
+
+// websocketStub answers the transport requests that pass the middlewares with 200
+type websocketStub struct{}
+
+func (websocketStub) WebsocketHandler(checkOrigin func(r *http.Request) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !checkOrigin(r) {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	})
+}
+
+func (websocketStub) HTTPStreamHandler() http.Handler {
+	return http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+}
+func (websocketStub) SSEHandler() http.Handler {
+	return http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+}
+func (websocketStub) EmulationHandler() http.Handler {
+	return http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+}
+
+func newSuite(t *testing.T) *Suite {
+	mockService := mocks.NewCommentService(t)
+	mockAccess := mocks.NewAccessChecker(t)
+	origins, err := origin.NewChecker([]string{testOrigin}, true)
+	require.NoError(t, err)
+	handler := NewHandler(logger.Plug(), websocketStub{}, mockService, mockAccess, pkgjwt.NewHMACVerifier(testSecret), origins)
+	handler.RegisterRoutes()
+
+	return &Suite{mockService: mockService, mockAccess: mockAccess, handler: handler}
+}
+
+func newTestToken(t *testing.T, userID int64) string {
+	token := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, jwtlib.MapClaims{
+		"user_id": userID,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return signed
+}
+
+func (s *Suite) do(method, target, body, token string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	s.handler.Mux.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestHandler_listPostComments(t *testing.T) {
+	s := newSuite(t)
+
+	s.mockAccess.On("CanViewPost", mock.Anything, int64(7), "p1").Return(true, nil)
+	s.mockService.On("ListByPostID", mock.Anything, "p1", mock.MatchedBy(func(filter domain.Filter) bool {
+		return filter.Page == 2 && filter.PageSize == 5 && filter.SortOrder == domain.SortOrderAsc
+	})).Return([]domain.Comment{{ID: "1"}}, domain.PaginationMetadata{CurrentPage: 2}, nil)
+
+	response := s.do(http.MethodGet, "/api/v1/posts/p1/comments?page=2&page_size=5&sort_order=asc", "", newTestToken(t, 7))
+
+	require.Equal(t, http.StatusOK, response.Code)
+	var body commentsResponse
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, "1", body.Comments[0].ID)
+	assert.Equal(t, int32(2), body.Metadata.CurrentPage)
+}
+
+func TestHandler_listPostComments_InvalidQuery(t *testing.T) {
+	s := newSuite(t)
+
+	for _, query := range []string{"page=abc", "sort_by=likes", "sort_order=up", "cursor=not-a-cursor"} {
+		response := s.do(http.MethodGet, "/api/v1/posts/p1/comments?"+query, "", newTestToken(t, 7))
+		assert.Equal(t, http.StatusBadRequest, response.Code, query)
+	}
+}
+
+func TestHandler_listPostComments_FailPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          bool
+		allowed        bool
+		onCanView      error
+		expectedStatus int
+	}{
+		{name: "no token", expectedStatus: http.StatusUnauthorized},
+		{name: "post not visible", token: true, expectedStatus: http.StatusForbidden},
+		{name: "post not found", token: true, onCanView: domain.ErrPostNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuite(t)
+			var token string
+			if tc.token {
+				token = newTestToken(t, 7)
+				s.mockAccess.On("CanViewPost", mock.Anything, int64(7), "p1").Return(tc.allowed, tc.onCanView)
+			}
+
+			response := s.do(http.MethodGet, "/api/v1/posts/p1/comments", "", token)
+			assert.Equal(t, tc.expectedStatus, response.Code)
+		})
+	}
+}
+
+func TestHandler_getComment(t *testing.T) {
+	s := newSuite(t)
+
+	s.mockService.On("GetByID", mock.Anything, "1").Return(domain.Comment{ID: "1", PostID: "p1"}, nil)
+	s.mockService.On("GetByID", mock.Anything, "2").Return(domain.Comment{}, domain.ErrCommentNotFound)
+	s.mockService.On("GetByID", mock.Anything, "3").Return(domain.Comment{ID: "3", PostID: "p2"}, nil)
+	s.mockAccess.On("CanViewPost", mock.Anything, int64(7), "p1").Return(true, nil)
+	s.mockAccess.On("CanViewPost", mock.Anything, int64(7), "p2").Return(false, nil)
+
+	response := s.do(http.MethodGet, "/api/v1/comments/1", "", newTestToken(t, 7))
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	response = s.do(http.MethodGet, "/api/v1/comments/2", "", newTestToken(t, 7))
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.JSONEq(t, `{"error":"comment not found"}`, response.Body.String())
+
+	response = s.do(http.MethodGet, "/api/v1/comments/3", "", newTestToken(t, 7))
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.JSONEq(t, `{"error":"comment not found"}`, response.Body.String())
+
+	response = s.do(http.MethodGet, "/api/v1/comments/1", "", "")
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+}
+
+func TestHandler_createComment(t *testing.T) {
+	s := newSuite(t)
+
+	s.mockAccess.On("CanViewPost", mock.Anything, int64(7), "p1").Return(true, nil)
+	s.mockService.On("Create", mock.Anything, commentservice.CreateCommentDTO{PostID: "p1", Body: "hello", UserID: 7}).
+		Return(domain.Comment{ID: "1", PostID: "p1", Body: "hello"}, nil)
+
+	response := s.do(http.MethodPost, "/api/v1/posts/p1/comments", `{"body":"hello"}`, newTestToken(t, 7))
+
+	require.Equal(t, http.StatusCreated, response.Code)
+	var body commentResponse
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	assert.Equal(t, "1", body.Comment.ID)
+}
+
+func TestHandler_createComment_FailPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		token          string
+		allowed        bool
+		onCreate       error
+		expectedStatus int
+	}{
+		{name: "no token", body: `{"body":"hello"}`, expectedStatus: http.StatusUnauthorized},
+		{name: "invalid token", body: `{"body":"hello"}`, token: "abc", expectedStatus: http.StatusUnauthorized},
+		{name: "invalid json", body: `{`, token: "valid", expectedStatus: http.StatusBadRequest},
+		{name: "empty body", body: `{}`, token: "valid", expectedStatus: http.StatusBadRequest},
+		{name: "body too large", body: `{"body":"` + strings.Repeat("a", maxBodySize) + `"}`, token: "valid", expectedStatus: http.StatusRequestEntityTooLarge},
+		{name: "post not visible", body: `{"body":"hello"}`, token: "valid", expectedStatus: http.StatusForbidden},
+		{name: "post not found", body: `{"body":"hello"}`, token: "valid", allowed: true, onCreate: domain.ErrPostNotFound, expectedStatus: http.StatusNotFound},
+		{name: "rejected by moderation", body: `{"body":"spam"}`, token: "valid", allowed: true, onCreate: domain.CommentRejectedError{Reason: "body contains banned words"}, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "rate limited", body: `{"body":"hello"}`, token: "valid", allowed: true, onCreate: domain.ErrTooManyRequests, expectedStatus: http.StatusTooManyRequests},
+		{name: "internal error", body: `{"body":"hello"}`, token: "valid", allowed: true, onCreate: domain.ErrInternal, expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuite(t)
+			if tc.onCreate != nil {
+				s.mockService.On("Create", mock.Anything, mock.Anything).Return(domain.Comment{}, tc.onCreate)
+			}
+			token := tc.token
+			if token == "valid" {
+				token = newTestToken(t, 7)
+				// the invalid bodies are rejected before the post visibility is checked
+				s.mockAccess.On("CanViewPost", mock.Anything, int64(7), "p1").Return(tc.allowed, nil).Maybe()
+			}
+
+			response := s.do(http.MethodPost, "/api/v1/posts/p1/comments", tc.body, token)
+			assert.Equal(t, tc.expectedStatus, response.Code)
+		})
+	}
+}
+
+func TestHandler_createComment_UnsupportedMediaType(t *testing.T) {
+	s := newSuite(t)
+
+	for _, contentType := range []string{"", "text/plain", "application/x-www-form-urlencoded"} {
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/posts/p1/comments", strings.NewReader(`{"body":"hello"}`))
+		request.Header.Set("Authorization", "Bearer "+newTestToken(t, 7))
+		if contentType != "" {
+			request.Header.Set("Content-Type", contentType)
+		}
+		recorder := httptest.NewRecorder()
+
+		s.handler.Mux.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, recorder.Code, contentType)
+	}
+}
+
+func TestHandler_updateComment(t *testing.T) {
+	s := newSuite(t)
+
+	s.mockService.On("Update", mock.Anything, commentservice.UpdateCommentDTO{CommentID: "1", Body: "edited", UserID: 7}).
+		Return(domain.Comment{}, domain.ErrUnauthorized)
+
+	response := s.do(http.MethodPatch, "/api/v1/comments/1", `{"body":"edited"}`, newTestToken(t, 7))
+	assert.Equal(t, http.StatusForbidden, response.Code)
+}
+
+func TestHandler_deleteComment(t *testing.T) {
+	s := newSuite(t)
+
+	s.mockService.On("Delete", mock.Anything, commentservice.DeleteCommentDTO{CommentID: "1", UserID: 7}).Return(nil)
+
+	response := s.do(http.MethodDelete, "/api/v1/comments/1", "", newTestToken(t, 7))
+	assert.Equal(t, http.StatusNoContent, response.Code)
+}
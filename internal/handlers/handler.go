@@ -1,25 +1,75 @@
 package handlers
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/jwt"
 )
 
 type Handler struct {
-	log       *slog.Logger
-	wsHandler WebsocketHandler
+	log            *slog.Logger
+	wsHandler      WebsocketHandler
+	commentService CommentService
+	accessChecker  AccessChecker
+	tokenVerifier  TokenVerifier
+	origins        OriginChecker
 
 	Mux *http.ServeMux
 }
 
+// WebsocketHandler provides the handlers of the realtime transports
 type WebsocketHandler interface {
-	WebsocketHandler() http.Handler
+	WebsocketHandler(checkOrigin func(r *http.Request) bool) http.Handler
+	HTTPStreamHandler() http.Handler
+	SSEHandler() http.Handler
+	EmulationHandler() http.Handler
+}
+
+// OriginChecker decides which browser origins may open realtime connections
+type OriginChecker interface {
+	Allowed(origin string) bool
+}
+
+// AccessChecker tells whether the user may read the comments of the post, the same check guards the websocket channels
+//
+//go:generate mockery --name AccessChecker
+type AccessChecker interface {
+	CanViewPost(ctx context.Context, userID int64, postID string) (bool, error)
+}
+
+// TokenVerifier verifies the JWT of the request
+type TokenVerifier interface {
+	Verify(tokenString string) (jwt.Claims, error)
+}
+
+//go:generate mockery --name CommentService
+type CommentService interface {
+	GetByID(ctx context.Context, id string) (domain.Comment, error)
+	ListByPostID(ctx context.Context, postID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error)
+	Create(ctx context.Context, comment commentservice.CreateCommentDTO) (domain.Comment, error)
+	Update(ctx context.Context, dto commentservice.UpdateCommentDTO) (domain.Comment, error)
+	Delete(ctx context.Context, dto commentservice.DeleteCommentDTO) error
 }
 
-func NewHandler(log *slog.Logger, wsHandler WebsocketHandler) *Handler {
+func NewHandler(
+	log *slog.Logger,
+	wsHandler WebsocketHandler,
+	commentService CommentService,
+	accessChecker AccessChecker,
+	tokenVerifier TokenVerifier,
+	origins OriginChecker,
+) *Handler {
 	return &Handler{
-		log:       log,
-		Mux:       http.NewServeMux(),
-		wsHandler: wsHandler,
+		log:            log,
+		Mux:            http.NewServeMux(),
+		wsHandler:      wsHandler,
+		commentService: commentService,
+		accessChecker:  accessChecker,
+		tokenVerifier:  tokenVerifier,
+		origins:        origins,
 	}
 }
@@ -1,40 +1,166 @@
 package handlers
 
-// func authMiddleware(h http.Handler) http.Handler {
-// 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-// 		authHeader := r.Header.Get("Authorization")
-// 		if authHeader == "" {
-// 			http.Error(w, "Authorization header missing", http.StatusUnauthorized)
-// 			return
-// 		}
-
-// 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-
-// 		userID, err := jwt.GetUserID(tokenString, os.Getenv("JWT_SECRET"))
-// 		if err != nil {
-// 			switch {
-// 			case errors.Is(err, domain.ErrTokenIsNotValid),
-// 				errors.Is(err, domain.ErrInvalidTokenClaims),
-// 				errors.Is(err, domain.ErrUserIDClaimNotFound),
-// 				errors.Is(err, domain.ErrTokenIsExpired),
-// 				errors.Is(err, domain.ErrUnexpectedSigningMethod):
-
-// 				w.WriteHeader(http.StatusUnauthorized)
-// 				w.Write([]byte(err.Error()))
-// 			default:
-// 				// todo: change log to slog
-// 				log.Print("failed to get user id from jwt token", logger.Err(err))
-// 				w.WriteHeader(http.StatusInternalServerError)
-// 			}
-// 			return
-// 		}
-
-// 		ctx := r.Context()
-// 		newCtx := centrifuge.SetCredentials(ctx, &centrifuge.Credentials{
-// 			UserID:   strconv.FormatInt(userID, 10),
-// 			ExpireAt: time.Now().Unix() + 60,
-// 		})
-// 		r = r.WithContext(newCtx)
-// 		h.ServeHTTP(w, r)
-// 	})
-// }
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/centrifugal/centrifuge"
+)
+
+// TokenCookie is the name of the cookie holding the JWT when the client does not send the Authorization header.
+// It is only accepted by the connection routes, their origin is checked, the REST API takes the header only,
+// so a cross-site request can not change comments with the cookie of the user.
+const TokenCookie = "access_token"
+
+// Middleware wraps a handler with additional behaviour
+type Middleware func(http.Handler) http.Handler
+
+// chain applies the middlewares to the handler, the first middleware is the outermost one
+func chain(h http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// AuthUser is the user authenticated for the request
+type AuthUser struct {
+	ID      int64
+	Roles   []string
+	ClubIDs []int64
+	// ExpiresAt is the unix time the token of the user expires at
+	ExpiresAt int64
+}
+
+type authUserKey struct{}
+
+func withAuthUser(ctx context.Context, user AuthUser) context.Context {
+	return context.WithValue(ctx, authUserKey{}, user)
+}
+
+// AuthUserFromContext returns the user set by the auth middleware
+func AuthUserFromContext(ctx context.Context) (AuthUser, bool) {
+	user, ok := ctx.Value(authUserKey{}).(AuthUser)
+	return user, ok
+}
+
+// requireAuth rejects the requests without a valid bearer token and puts the user into the request context
+func (h *Handler) requireAuth(next http.Handler) http.Handler {
+	return h.authMiddleware(next, true, bearerToken)
+}
+
+// connectionAuth authenticates the connection request with the header or cookie token and passes the user to centrifuge.
+// Requests without a token are passed through, the client then has to send its token in the connect command.
+func (h *Handler) connectionAuth(next http.Handler) http.Handler {
+	return h.authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, ok := AuthUserFromContext(r.Context()); ok {
+			r = r.WithContext(centrifuge.SetCredentials(r.Context(), &centrifuge.Credentials{
+				UserID:   strconv.FormatInt(user.ID, 10),
+				ExpireAt: user.ExpiresAt,
+			}))
+		}
+		next.ServeHTTP(w, r)
+	}), false, connectionToken)
+}
+
+// checkOrigin reports whether the origin of the websocket upgrade request is allowed
+func (h *Handler) checkOrigin(r *http.Request) bool {
+	return h.origins.Allowed(r.Header.Get("Origin"))
+}
+
+// allowOrigin rejects the requests of the origins that are not allowed and answers the CORS preflight of the allowed
+// ones, the browsers send the HTTP-streaming, SSE and emulation requests as cross-origin fetches
+func (h *Handler) allowOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if !h.origins.Allowed(origin) {
+			writeError(w, http.StatusForbidden, "origin is not allowed")
+			return
+		}
+
+		if origin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Add("Vary", "Origin")
+		}
+
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authMiddleware verifies the token read from the request by token
+func (h *Handler) authMiddleware(next http.Handler, required bool, token func(r *http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.middleware.auth"
+		log := h.log.With(slog.String("op", op))
+
+		tokenString := token(r)
+		if tokenString == "" {
+			if required {
+				writeError(w, http.StatusUnauthorized, "authorization token is missing")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, err := h.tokenVerifier.Verify(tokenString)
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrTokenIsNotValid),
+				errors.Is(err, domain.ErrInvalidTokenClaims),
+				errors.Is(err, domain.ErrUserIDClaimNotFound),
+				errors.Is(err, domain.ErrTokenIsExpired),
+				errors.Is(err, domain.ErrUnexpectedSigningMethod),
+				errors.Is(err, domain.ErrTokenNotValidYet),
+				errors.Is(err, domain.ErrTokenInvalidIssuer),
+				errors.Is(err, domain.ErrTokenInvalidAudience):
+				writeError(w, http.StatusUnauthorized, err.Error())
+			default:
+				log.Error("failed to verify the token", logger.Err(err))
+				writeError(w, http.StatusInternalServerError, domain.ErrInternal.Error())
+			}
+			return
+		}
+
+		ctx := withAuthUser(r.Context(), AuthUser{
+			ID:        claims.UserID,
+			Roles:     claims.Roles,
+			ClubIDs:   claims.ClubIDs,
+			ExpiresAt: claims.ExpiresAt.Unix(),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// bearerToken returns the bearer token of the Authorization header
+func bearerToken(r *http.Request) string {
+	tokenString, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return strings.TrimSpace(tokenString)
+}
+
+// connectionToken returns the bearer token, or the token cookie when there is no Authorization header
+func connectionToken(r *http.Request) string {
+	if r.Header.Get("Authorization") != "" {
+		return bearerToken(r)
+	}
+
+	cookie, err := r.Cookie(TokenCookie)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pkgjwt "github.com/ARUMANDESU/uniclubs-comments-service/pkg/jwt"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/centrifugal/centrifuge"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func TestHandler_requireAuth(t *testing.T) {
+	h := NewHandler(logger.Plug(), nil, nil, nil, pkgjwt.NewHMACVerifier(testSecret), nil)
+
+	var user AuthUser
+	next := h.requireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _ = AuthUserFromContext(r.Context())
+	}))
+
+	t.Run("bearer header", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Authorization", "Bearer "+newTestToken(t, 7))
+		recorder := httptest.NewRecorder()
+
+		next.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, int64(7), user.ID)
+		assert.NotZero(t, user.ExpiresAt)
+	})
+
+	t.Run("cookie is not accepted", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "/", nil)
+		request.AddCookie(&http.Cookie{Name: TokenCookie, Value: newTestToken(t, 8)})
+		recorder := httptest.NewRecorder()
+
+		next.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
+
+func TestHandler_requireAuth_FailPath(t *testing.T) {
+	expired, err := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, jwtlib.MapClaims{
+		"user_id": 7,
+		"exp":     time.Now().Add(-time.Hour).Unix(),
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer jwksServer.Close()
+	rsaToken, err := jwtlib.NewWithClaims(jwtlib.SigningMethodRS256, jwtlib.MapClaims{"user_id": 7}).SignedString(newRSAKey(t))
+	require.NoError(t, err)
+
+	hmacVerifier := pkgjwt.NewHMACVerifier(testSecret)
+	tests := []struct {
+		name           string
+		verifier       TokenVerifier
+		header         string
+		expectedStatus int
+	}{
+		{name: "missing token", verifier: hmacVerifier, expectedStatus: http.StatusUnauthorized},
+		{name: "malformed token", verifier: hmacVerifier, header: "Bearer abc", expectedStatus: http.StatusUnauthorized},
+		{name: "expired token", verifier: hmacVerifier, header: "Bearer " + expired, expectedStatus: http.StatusUnauthorized},
+		{name: "wrong secret", verifier: pkgjwt.NewHMACVerifier("other-secret"), header: "Bearer " + expired, expectedStatus: http.StatusUnauthorized},
+		{
+			name:           "key set unavailable",
+			verifier:       pkgjwt.NewJWKSVerifier(pkgjwt.NewJWKS(jwksServer.URL, time.Hour)),
+			header:         "Bearer " + rsaToken,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHandler(logger.Plug(), nil, nil, nil, tc.verifier, nil)
+			next := h.requireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("next handler must not be called")
+			}))
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				request.Header.Set("Authorization", tc.header)
+			}
+			recorder := httptest.NewRecorder()
+
+			next.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), `"error"`)
+		})
+	}
+}
+
+func TestHandler_connectionAuth(t *testing.T) {
+	h := NewHandler(logger.Plug(), nil, nil, nil, pkgjwt.NewHMACVerifier(testSecret), nil)
+
+	var (
+		credentials *centrifuge.Credentials
+		ok          bool
+	)
+	next := h.connectionAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		credentials, ok = centrifuge.GetCredentials(r.Context())
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/connection/websocket", nil)
+	request.Header.Set("Authorization", "Bearer "+newTestToken(t, 7))
+	next.ServeHTTP(httptest.NewRecorder(), request)
+
+	require.True(t, ok)
+	assert.Equal(t, "7", credentials.UserID)
+
+	// browsers can not set the header of the upgrade request, the connection routes take the cookie
+	request = httptest.NewRequest(http.MethodGet, "/connection/websocket", nil)
+	request.AddCookie(&http.Cookie{Name: TokenCookie, Value: newTestToken(t, 8)})
+	next.ServeHTTP(httptest.NewRecorder(), request)
+
+	require.True(t, ok)
+	assert.Equal(t, "8", credentials.UserID)
+
+	// without a token the client authenticates with the connect command
+	next.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/connection/websocket", nil))
+	assert.False(t, ok)
+
+	recorder := httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodGet, "/connection/websocket", nil)
+	request.Header.Set("Authorization", "Bearer abc")
+	next.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestHandler_allowOrigin(t *testing.T) {
+	s := newSuite(t)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		origin         string
+		expectedStatus int
+		expectedCORS   bool
+	}{
+		{name: "sse of allowed origin", method: http.MethodGet, target: "/connection/sse", origin: testOrigin, expectedStatus: http.StatusOK, expectedCORS: true},
+		{name: "http stream of allowed origin", method: http.MethodPost, target: "/connection/http_stream", origin: testOrigin, expectedStatus: http.StatusOK, expectedCORS: true},
+		{name: "emulation of allowed origin", method: http.MethodPost, target: "/emulation", origin: testOrigin, expectedStatus: http.StatusOK, expectedCORS: true},
+		{name: "preflight", method: http.MethodOptions, target: "/connection/http_stream", origin: testOrigin, expectedStatus: http.StatusNoContent, expectedCORS: true},
+		{name: "without origin", method: http.MethodGet, target: "/connection/sse", expectedStatus: http.StatusOK},
+		{name: "sse of other origin", method: http.MethodGet, target: "/connection/sse", origin: "https://evil.com", expectedStatus: http.StatusForbidden},
+		{name: "preflight of other origin", method: http.MethodOptions, target: "/emulation", origin: "https://evil.com", expectedStatus: http.StatusForbidden},
+		{name: "websocket of allowed origin", method: http.MethodGet, target: "/connection/websocket", origin: testOrigin, expectedStatus: http.StatusOK},
+		{name: "websocket of other origin", method: http.MethodGet, target: "/connection/websocket", origin: "https://evil.com", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.method, tc.target, nil)
+			if tc.origin != "" {
+				request.Header.Set("Origin", tc.origin)
+			}
+			recorder := httptest.NewRecorder()
+
+			s.handler.Mux.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.expectedCORS {
+				assert.Equal(t, tc.origin, recorder.Header().Get("Access-Control-Allow-Origin"))
+				assert.Equal(t, "true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+			} else {
+				assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+)
+
+// errorResponse is the body of every failed request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+// handleErr writes the response matching the domain error, unknown errors are logged and hidden behind 500
+func handleErr(log *slog.Logger, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidID),
+		errors.Is(err, domain.ErrInvalidArg),
+		errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrParentPostMismatch),
+		errors.Is(err, domain.ErrParentUnavailable),
+		errors.Is(err, domain.ErrInvalidReaction):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, errUnsupportedMediaType):
+		writeError(w, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, errBodyTooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, domain.ErrUnauthorized),
+		errors.Is(err, domain.ErrUserBanned):
+		writeError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrCommentNotFound),
+		errors.Is(err, domain.ErrPostNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrCommentNotDeleted):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrTooManyRequests):
+		writeError(w, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, domain.ErrCommentRejected):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		log.Error("failed to handle request", logger.Err(err))
+		writeError(w, http.StatusInternalServerError, domain.ErrInternal.Error())
+	}
+}
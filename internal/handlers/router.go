@@ -1,5 +1,16 @@
 package handlers
 
+import "net/http"
+
 func (h *Handler) RegisterRoutes() {
-	h.Mux.Handle("/connection/websocket", h.wsHandler.WebsocketHandler())
+	h.Mux.Handle("/connection/websocket", chain(h.wsHandler.WebsocketHandler(h.checkOrigin), h.connectionAuth))
+	h.Mux.Handle("/connection/http_stream", chain(h.wsHandler.HTTPStreamHandler(), h.allowOrigin, h.connectionAuth))
+	h.Mux.Handle("/connection/sse", chain(h.wsHandler.SSEHandler(), h.allowOrigin, h.connectionAuth))
+	h.Mux.Handle("/emulation", chain(h.wsHandler.EmulationHandler(), h.allowOrigin))
+
+	h.Mux.Handle("GET /api/v1/posts/{post_id}/comments", chain(http.HandlerFunc(h.listPostComments), h.requireAuth))
+	h.Mux.Handle("POST /api/v1/posts/{post_id}/comments", chain(http.HandlerFunc(h.createComment), h.requireAuth))
+	h.Mux.Handle("GET /api/v1/comments/{id}", chain(http.HandlerFunc(h.getComment), h.requireAuth))
+	h.Mux.Handle("PATCH /api/v1/comments/{id}", chain(http.HandlerFunc(h.updateComment), h.requireAuth))
+	h.Mux.Handle("DELETE /api/v1/comments/{id}", chain(http.HandlerFunc(h.deleteComment), h.requireAuth))
 }
@@ -0,0 +1,28 @@
+// Package moderation provides the content checks of the comment moderation pipeline.
+package moderation
+
+import (
+	"fmt"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+const (
+	ActionMask   = "mask"
+	ActionReview = "review"
+	ActionReject = "reject"
+)
+
+// ParseAction returns the verdict of a check for the configured action: mask, review or reject
+func ParseAction(action string) (domain.ModerationStatus, error) {
+	switch action {
+	case ActionMask:
+		return domain.ModerationMasked, nil
+	case ActionReview:
+		return domain.ModerationPending, nil
+	case ActionReject:
+		return domain.ModerationRejected, nil
+	default:
+		return "", fmt.Errorf("unknown moderation action %q, expected mask, review or reject", action)
+	}
+}
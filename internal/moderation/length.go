@@ -0,0 +1,35 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+// LengthCheck rejects empty bodies and bodies longer than the limit
+type LengthCheck struct {
+	maxLength int
+}
+
+// NewLengthCheck creates the check, maxLength is counted in characters and 0 means no limit
+func NewLengthCheck(maxLength int) LengthCheck {
+	return LengthCheck{maxLength: maxLength}
+}
+
+func (c LengthCheck) Check(_ context.Context, comment domain.Comment) (domain.ModerationResult, error) {
+	if strings.TrimSpace(comment.Body) == "" {
+		return domain.ModerationResult{Status: domain.ModerationRejected, Reason: "body is empty"}, nil
+	}
+
+	if c.maxLength > 0 && utf8.RuneCountInString(comment.Body) > c.maxLength {
+		return domain.ModerationResult{
+			Status: domain.ModerationRejected,
+			Reason: fmt.Sprintf("body is longer than %d characters", c.maxLength),
+		}, nil
+	}
+
+	return domain.Approve(), nil
+}
@@ -0,0 +1,34 @@
+package moderation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLengthCheck_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected domain.ModerationStatus
+	}{
+		{name: "ok", body: "hello", expected: domain.ModerationApproved},
+		{name: "max length in characters", body: strings.Repeat("ә", 10), expected: domain.ModerationApproved},
+		{name: "empty", body: "", expected: domain.ModerationRejected},
+		{name: "whitespace", body: " \n\t ", expected: domain.ModerationRejected},
+		{name: "too long", body: strings.Repeat("a", 11), expected: domain.ModerationRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewLengthCheck(10).Check(context.Background(), domain.Comment{Body: tt.body})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Status)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+// removedLink replaces the links of a masked body
+const removedLink = "[link removed]"
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// LinkCheck limits the number of links in the body
+type LinkCheck struct {
+	maxLinks int
+	status   domain.ModerationStatus
+}
+
+// NewLinkCheck creates the check, status is the verdict for a body with more than maxLinks links
+func NewLinkCheck(maxLinks int, status domain.ModerationStatus) LinkCheck {
+	return LinkCheck{maxLinks: maxLinks, status: status}
+}
+
+func (c LinkCheck) Check(_ context.Context, comment domain.Comment) (domain.ModerationResult, error) {
+	links := linkPattern.FindAllStringIndex(comment.Body, c.maxLinks+1)
+	if len(links) <= c.maxLinks {
+		return domain.Approve(), nil
+	}
+
+	body := comment.Body
+	if c.status == domain.ModerationMasked {
+		body = linkPattern.ReplaceAllString(body, removedLink)
+	}
+
+	return domain.ModerationResult{
+		Status: c.status,
+		Body:   body,
+		Reason: fmt.Sprintf("body contains more than %d links", c.maxLinks),
+	}, nil
+}
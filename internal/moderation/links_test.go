@@ -0,0 +1,39 @@
+package moderation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkCheck_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected domain.ModerationStatus
+	}{
+		{name: "no links", body: "hello", expected: domain.ModerationApproved},
+		{name: "at the limit", body: "see https://a.kz and www.b.kz", expected: domain.ModerationApproved},
+		{name: "over the limit", body: "https://a.kz http://b.kz WWW.c.kz", expected: domain.ModerationPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewLinkCheck(2, domain.ModerationPending).Check(context.Background(), domain.Comment{Body: tt.body})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Status)
+		})
+	}
+}
+
+func TestLinkCheck_Check_Mask(t *testing.T) {
+	result, err := NewLinkCheck(0, domain.ModerationMasked).Check(context.Background(), domain.Comment{Body: "go to https://a.kz now"})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ModerationMasked, result.Status)
+	assert.Equal(t, "go to [link removed] now", result.Body)
+}
@@ -0,0 +1,96 @@
+package moderation
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+// SpamCheck detects a user posting the same message again and again.
+// The recent messages are kept in memory, so every instance of the service counts its own.
+type SpamCheck struct {
+	window  time.Duration
+	repeats int
+	status  domain.ModerationStatus
+	now     func() time.Time
+
+	mu        sync.Mutex
+	recent    map[int64][]message
+	lastSweep time.Time
+}
+
+type message struct {
+	body   string
+	sentAt time.Time
+}
+
+// NewSpamCheck creates the check, status is the verdict for a message the user already sent repeats times within the window
+func NewSpamCheck(window time.Duration, repeats int, status domain.ModerationStatus) *SpamCheck {
+	return &SpamCheck{
+		window:  window,
+		repeats: max(repeats, 1),
+		status:  status,
+		now:     time.Now,
+		recent:  make(map[int64][]message),
+	}
+}
+
+func (c *SpamCheck) Check(_ context.Context, comment domain.Comment) (domain.ModerationResult, error) {
+	now := c.now()
+	body := normalize(comment.Body)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var sent int
+	for _, m := range c.unexpired(c.recent[comment.User.ID], now) {
+		if m.body == body {
+			sent++
+		}
+	}
+
+	if sent < c.repeats {
+		return domain.Approve(), nil
+	}
+
+	return domain.ModerationResult{Status: c.status, Body: comment.Body, Reason: "the same message is sent repeatedly"}, nil
+}
+
+// Record counts the message of the created comment, only the messages the whole pipeline accepted are counted
+func (c *SpamCheck) Record(_ context.Context, comment domain.Comment) {
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.lastSweep) > c.window {
+		for userID, messages := range c.recent {
+			if messages = c.unexpired(messages, now); len(messages) == 0 {
+				delete(c.recent, userID)
+			} else {
+				c.recent[userID] = messages
+			}
+		}
+		c.lastSweep = now
+	}
+
+	messages := c.unexpired(c.recent[comment.User.ID], now)
+	c.recent[comment.User.ID] = append(messages, message{body: normalize(comment.Body), sentAt: now})
+}
+
+// unexpired drops the messages sent before the window
+func (c *SpamCheck) unexpired(messages []message, now time.Time) []message {
+	i := 0
+	for i < len(messages) && now.Sub(messages[i].sentAt) > c.window {
+		i++
+	}
+	return messages[i:]
+}
+
+// normalize makes the messages differing only in case and whitespace equal
+func normalize(body string) string {
+	return strings.Join(strings.Fields(strings.ToLower(body)), " ")
+}
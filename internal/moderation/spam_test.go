@@ -0,0 +1,73 @@
+package moderation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSpamCheck() (*SpamCheck, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	check := NewSpamCheck(time.Minute, 2, domain.ModerationRejected)
+	check.now = func() time.Time { return now }
+	return check, &now
+}
+
+// spamStatus checks the message and records it like the pipeline does when the comment is created
+func spamStatus(t *testing.T, check *SpamCheck, userID int64, body string) domain.ModerationStatus {
+	comment := domain.Comment{User: domain.User{ID: userID}, Body: body}
+	result, err := check.Check(context.Background(), comment)
+	require.NoError(t, err)
+	if result.Status != domain.ModerationRejected {
+		check.Record(context.Background(), comment)
+	}
+	return result.Status
+}
+
+func TestSpamCheck_Check(t *testing.T) {
+	check, now := newTestSpamCheck()
+
+	assert.Equal(t, domain.ModerationApproved, spamStatus(t, check, 1, "buy now"))
+	assert.Equal(t, domain.ModerationApproved, spamStatus(t, check, 1, "Buy   NOW"))
+	assert.Equal(t, domain.ModerationRejected, spamStatus(t, check, 1, "buy now"))
+	// other messages and other users are not affected
+	assert.Equal(t, domain.ModerationApproved, spamStatus(t, check, 1, "hello"))
+	assert.Equal(t, domain.ModerationApproved, spamStatus(t, check, 2, "buy now"))
+
+	*now = now.Add(2 * time.Minute)
+	assert.Equal(t, domain.ModerationApproved, spamStatus(t, check, 1, "buy now"))
+}
+
+func TestSpamCheck_CountsOnlyRecordedMessages(t *testing.T) {
+	check, _ := newTestSpamCheck()
+	comment := domain.Comment{User: domain.User{ID: 1}, Body: "buy now"}
+
+	// checking an edit or a comment rejected by a later check does not count the message
+	for range 3 {
+		result, err := check.Check(context.Background(), comment)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ModerationApproved, result.Status)
+	}
+
+	check.Record(context.Background(), comment)
+	check.Record(context.Background(), comment)
+	result, err := check.Check(context.Background(), comment)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ModerationRejected, result.Status)
+}
+
+func TestSpamCheck_DropsExpiredMessages(t *testing.T) {
+	check, now := newTestSpamCheck()
+
+	spamStatus(t, check, 1, "hello")
+	spamStatus(t, check, 2, "hello")
+	assert.Len(t, check.recent, 2)
+
+	*now = now.Add(2 * time.Minute)
+	spamStatus(t, check, 3, "hello")
+	assert.Len(t, check.recent, 1)
+}
@@ -0,0 +1,106 @@
+package moderation
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+// BannedWordsCheck finds the banned words in the body, the words are matched whole and case-insensitively
+type BannedWordsCheck struct {
+	words  map[string]struct{}
+	status domain.ModerationStatus
+}
+
+// NewBannedWordsCheck creates the check, status is the verdict for a body with banned words.
+// A masked body has every letter of the banned words replaced with an asterisk.
+func NewBannedWordsCheck(words []string, status domain.ModerationStatus) BannedWordsCheck {
+	set := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" {
+			set[word] = struct{}{}
+		}
+	}
+
+	return BannedWordsCheck{words: set, status: status}
+}
+
+// LoadWords reads the words of the file, one word per line, empty lines and lines starting with # are skipped
+func LoadWords(path string) ([]string, error) {
+	const op = "moderation.load_words"
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return words, nil
+}
+
+func (c BannedWordsCheck) Check(_ context.Context, comment domain.Comment) (domain.ModerationResult, error) {
+	if len(c.words) == 0 {
+		return domain.Approve(), nil
+	}
+
+	var (
+		masked strings.Builder
+		found  bool
+	)
+	body := comment.Body
+	for len(body) > 0 {
+		// the body is split into the runs of letters and digits and the separators between them
+		end := wordEnd(body)
+		if end == 0 {
+			_, size := utf8.DecodeRuneInString(body)
+			masked.WriteString(body[:size])
+			body = body[size:]
+			continue
+		}
+
+		word := body[:end]
+		if _, ok := c.words[strings.ToLower(word)]; ok {
+			found = true
+			masked.WriteString(strings.Repeat("*", utf8.RuneCountInString(word)))
+		} else {
+			masked.WriteString(word)
+		}
+		body = body[end:]
+	}
+
+	if !found {
+		return domain.Approve(), nil
+	}
+
+	return domain.ModerationResult{Status: c.status, Body: masked.String(), Reason: "body contains banned words"}, nil
+}
+
+// wordEnd returns the length of the word at the start of s, 0 if s starts with a separator
+func wordEnd(s string) int {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return i
+		}
+	}
+	return len(s)
+}
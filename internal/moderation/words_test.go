@@ -0,0 +1,62 @@
+package moderation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBannedWordsCheck_Check(t *testing.T) {
+	check := NewBannedWordsCheck([]string{"spam", " Жаман "}, domain.ModerationMasked)
+
+	tests := []struct {
+		name         string
+		body         string
+		expected     domain.ModerationStatus
+		expectedBody string
+	}{
+		{name: "clean", body: "hello there", expected: domain.ModerationApproved},
+		{name: "banned word", body: "buy spam now", expected: domain.ModerationMasked, expectedBody: "buy **** now"},
+		{name: "case insensitive", body: "SPAM, Spam!", expected: domain.ModerationMasked, expectedBody: "****, ****!"},
+		{name: "cyrillic", body: "бұл жаман сөз", expected: domain.ModerationMasked, expectedBody: "бұл ***** сөз"},
+		{name: "part of a word", body: "spammer", expected: domain.ModerationApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := check.Check(context.Background(), domain.Comment{Body: tt.body})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Status)
+			if tt.expected != domain.ModerationApproved {
+				assert.Equal(t, tt.expectedBody, result.Body)
+			}
+		})
+	}
+}
+
+func TestBannedWordsCheck_Check_Reject(t *testing.T) {
+	result, err := NewBannedWordsCheck([]string{"spam"}, domain.ModerationRejected).Check(context.Background(), domain.Comment{Body: "spam"})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ModerationRejected, result.Status)
+	assert.NotEmpty(t, result.Reason)
+}
+
+func TestLoadWords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# insults\nspam\n\n  scam  \n"), 0o600))
+
+	words, err := LoadWords(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spam", "scam"}, words)
+
+	_, err = LoadWords(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
@@ -15,6 +15,16 @@ const (
 	UserExchangeName           = "user-exchange"
 	UserEventsQueue            = "user-events-comment-queue"
 	UserUpdatedEventRoutingKey = "user.event.updated"
+	UserBannedEventRoutingKey  = "user.event.banned"
+)
+
+// The comment exchange carries the events of this service to the other services
+const (
+	CommentExchangeName             = "comment-exchange"
+	CommentCreatedEventRoutingKey   = "comment.event.created"
+	CommentUpdatedEventRoutingKey   = "comment.event.updated"
+	CommentDeletedEventRoutingKey   = "comment.event.deleted"
+	CommentMentionedEventRoutingKey = "comment.event.mentioned"
 )
 
 type Handler func(msg amqp.Delivery) error
@@ -24,6 +34,8 @@ type Rabbitmq struct {
 	ch   *amqp.Channel
 	cfg  config.Rabbitmq
 	log  *slog.Logger
+	// userBannedQueue is the queue of this instance receiving the ban events
+	userBannedQueue string
 }
 
 func New(cfg config.Rabbitmq, log *slog.Logger) (*Rabbitmq, error) {
@@ -46,26 +58,32 @@ func New(cfg config.Rabbitmq, log *slog.Logger) (*Rabbitmq, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = declareQueues(ch)
+	userBannedQueue, err := declareQueues(ch)
 	if err != nil {
 		log.Error("failed to declare queues", logger.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = bindQueues(ch)
+	err = bindQueues(ch, userBannedQueue)
 	if err != nil {
 		log.Error("failed to bind queues", logger.Err(err))
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &Rabbitmq{
-		conn: conn,
-		ch:   ch,
-		cfg:  cfg,
-		log:  log,
+		conn:            conn,
+		ch:              ch,
+		cfg:             cfg,
+		log:             log,
+		userBannedQueue: userBannedQueue,
 	}, nil
 }
 
+// UserBannedQueue returns the name of the queue receiving the ban events, every instance has its own
+func (r *Rabbitmq) UserBannedQueue() string {
+	return r.userBannedQueue
+}
+
 func (r *Rabbitmq) Consume(queue, routingKey string, handler func(msg amqp.Delivery) error) error {
 	const op = "rabbitmq.consume"
 	log := r.log.With(
@@ -188,10 +206,24 @@ func declareExchanges(ch *amqp.Channel) error {
 		return err
 	}
 
+	err = ch.ExchangeDeclare(
+		CommentExchangeName,
+		"topic",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func declareQueues(ch *amqp.Channel) error {
+// declareQueues declares the queues consumed by the service and returns the name of the ban events queue
+func declareQueues(ch *amqp.Channel) (string, error) {
 	_, err := ch.QueueDeclare(
 		UserEventsQueue,
 		true,
@@ -201,13 +233,28 @@ func declareQueues(ch *amqp.Channel) error {
 		nil,
 	)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	// Consume requeues the messages of other routing keys, so every consumed routing key has its own queue.
+	// Every instance disconnects the banned users from its own connections, so each one needs every ban:
+	// the server named queue is exclusive to this connection and deleted with it.
+	userBannedQueue, err := ch.QueueDeclare(
+		"",
+		false,
+		true,
+		true,
+		false,
+		nil,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return userBannedQueue.Name, nil
 }
 
-func bindQueues(ch *amqp.Channel) error {
+func bindQueues(ch *amqp.Channel, userBannedQueue string) error {
 	err := ch.QueueBind(
 		UserEventsQueue,
 		UserUpdatedEventRoutingKey,
@@ -219,5 +266,16 @@ func bindQueues(ch *amqp.Channel) error {
 		return err
 	}
 
+	err = ch.QueueBind(
+		userBannedQueue,
+		UserBannedEventRoutingKey,
+		UserExchangeName,
+		false,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,115 @@
+// Package ratelimit limits how often comments are created with token buckets per user, per post and in total.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"golang.org/x/time/rate"
+)
+
+// Limiter allows a comment only when the buckets of the user, of the post and the global bucket all have a token
+type Limiter struct {
+	global *rate.Limiter
+	users  *keyedLimiter[int64]
+	posts  *keyedLimiter[string]
+	now    func() time.Time
+}
+
+// New creates the limiter, a bucket with a zero rate does not limit
+func New(cfg config.RateLimit) *Limiter {
+	return &Limiter{
+		global: newLimiter(cfg.GlobalRate, cfg.GlobalBurst),
+		users:  newKeyedLimiter[int64](cfg.UserRate, cfg.UserBurst),
+		posts:  newKeyedLimiter[string](cfg.PostRate, cfg.PostBurst),
+		now:    time.Now,
+	}
+}
+
+// AllowCreate takes a token from every bucket of the comment, no token is taken if any bucket is empty
+func (l *Limiter) AllowCreate(userID int64, postID string) bool {
+	now := l.now()
+
+	reservations := make([]*rate.Reservation, 0, 3)
+	for _, limiter := range []*rate.Limiter{l.users.get(userID, now), l.posts.get(postID, now), l.global} {
+		r := limiter.ReserveN(now, 1)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			for _, reserved := range reservations {
+				reserved.CancelAt(now)
+			}
+			return false
+		}
+		reservations = append(reservations, r)
+	}
+
+	return true
+}
+
+var unlimited = rate.NewLimiter(rate.Inf, 0)
+
+func newLimiter(r float64, burst int) *rate.Limiter {
+	if r <= 0 {
+		return unlimited
+	}
+	return rate.NewLimiter(rate.Limit(r), max(burst, 1))
+}
+
+// keyedLimiter keeps a bucket per key, the buckets idle long enough to be full again are dropped
+type keyedLimiter[K comparable] struct {
+	rate  float64
+	burst int
+	// idle is the time an empty bucket takes to refill, a dropped bucket is recreated full
+	idle time.Duration
+
+	mu        sync.Mutex
+	buckets   map[K]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newKeyedLimiter[K comparable](r float64, burst int) *keyedLimiter[K] {
+	var idle time.Duration
+	if r > 0 {
+		idle = time.Duration(float64(max(burst, 1)) / r * float64(time.Second))
+	}
+
+	return &keyedLimiter[K]{
+		rate:    r,
+		burst:   burst,
+		idle:    idle,
+		buckets: make(map[K]*bucket),
+	}
+}
+
+func (k *keyedLimiter[K]) get(key K, now time.Time) *rate.Limiter {
+	if k.rate <= 0 {
+		return unlimited
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if now.Sub(k.lastSweep) > k.idle {
+		for key, b := range k.buckets {
+			if now.Sub(b.lastSeen) > k.idle {
+				delete(k.buckets, key)
+			}
+		}
+		k.lastSweep = now
+	}
+
+	b, ok := k.buckets[key]
+	if !ok {
+		b = &bucket{limiter: newLimiter(k.rate, k.burst)}
+		k.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	return b.limiter
+}
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestLimiter returns the limiter with a clock the test moves forward
+func newTestLimiter(cfg config.RateLimit) (*Limiter, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(cfg)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiter_AllowCreate_User(t *testing.T) {
+	l, now := newTestLimiter(config.RateLimit{UserRate: 1, UserBurst: 2})
+
+	assert.True(t, l.AllowCreate(1, "post"))
+	assert.True(t, l.AllowCreate(1, "post"))
+	assert.False(t, l.AllowCreate(1, "post"))
+	// other users have their own bucket
+	assert.True(t, l.AllowCreate(2, "post"))
+
+	*now = now.Add(time.Second)
+	assert.True(t, l.AllowCreate(1, "post"))
+	assert.False(t, l.AllowCreate(1, "post"))
+}
+
+func TestLimiter_AllowCreate_Post(t *testing.T) {
+	l, _ := newTestLimiter(config.RateLimit{PostRate: 1, PostBurst: 1})
+
+	assert.True(t, l.AllowCreate(1, "post-1"))
+	assert.False(t, l.AllowCreate(2, "post-1"))
+	assert.True(t, l.AllowCreate(2, "post-2"))
+}
+
+func TestLimiter_AllowCreate_Global(t *testing.T) {
+	l, _ := newTestLimiter(config.RateLimit{GlobalRate: 1, GlobalBurst: 2})
+
+	assert.True(t, l.AllowCreate(1, "post-1"))
+	assert.True(t, l.AllowCreate(2, "post-2"))
+	assert.False(t, l.AllowCreate(3, "post-3"))
+}
+
+func TestLimiter_AllowCreate_RejectedTakesNoToken(t *testing.T) {
+	l, now := newTestLimiter(config.RateLimit{UserRate: 1, UserBurst: 1, GlobalRate: 1, GlobalBurst: 1})
+
+	assert.True(t, l.AllowCreate(1, "post"))
+	// the global bucket is empty, the bucket of user 2 must stay full
+	assert.False(t, l.AllowCreate(2, "post"))
+
+	*now = now.Add(time.Second)
+	assert.True(t, l.AllowCreate(2, "post"))
+}
+
+func TestLimiter_AllowCreate_Disabled(t *testing.T) {
+	l, _ := newTestLimiter(config.RateLimit{})
+
+	for i := 0; i < 1000; i++ {
+		assert.True(t, l.AllowCreate(1, "post"))
+	}
+}
+
+func TestLimiter_DropsIdleBuckets(t *testing.T) {
+	l, now := newTestLimiter(config.RateLimit{UserRate: 1, UserBurst: 2})
+
+	assert.True(t, l.AllowCreate(1, "post"))
+	assert.True(t, l.AllowCreate(2, "post"))
+	assert.Len(t, l.users.buckets, 2)
+
+	*now = now.Add(3 * time.Second)
+	assert.True(t, l.AllowCreate(3, "post"))
+	assert.Len(t, l.users.buckets, 1)
+}
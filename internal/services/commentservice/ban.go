@@ -0,0 +1,25 @@
+package commentservice
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+// BanChecker reports whether the user is banned, banned users can still read the comments but not change them
+//
+//go:generate mockery --name BanChecker
+type BanChecker interface {
+	IsRevoked(ctx context.Context, userID int64) bool
+}
+
+// checkBan returns ErrUserBanned when the user is banned, the check is made here so it covers every transport
+func (s Service) checkBan(ctx context.Context, log *slog.Logger, userID int64) error {
+	if s.bans == nil || !s.bans.IsRevoked(ctx, userID) {
+		return nil
+	}
+
+	log.Debug("banned user", slog.Int64("user_id", userID))
+	return domain.ErrUserBanned
+}
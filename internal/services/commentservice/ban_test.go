@@ -0,0 +1,95 @@
+package commentservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_Banned(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(s Service) error
+	}{
+		{name: "create", change: func(s Service) error {
+			_, err := s.Create(context.Background(), CreateCommentDTO{PostID: "p1", UserID: 7, Body: "hello"})
+			return err
+		}},
+		{name: "update", change: func(s Service) error {
+			_, err := s.Update(context.Background(), UpdateCommentDTO{CommentID: "c1", UserID: 7, Body: "hello"})
+			return err
+		}},
+		{name: "delete", change: func(s Service) error {
+			return s.Delete(context.Background(), DeleteCommentDTO{CommentID: "c1", UserID: 7})
+		}},
+		{name: "restore", change: func(s Service) error {
+			_, err := s.Restore(context.Background(), RestoreCommentDTO{CommentID: "c1", UserID: 7})
+			return err
+		}},
+		{name: "hide", change: func(s Service) error {
+			_, err := s.Hide(context.Background(), HideCommentDTO{CommentID: "c1", UserID: 7})
+			return err
+		}},
+		{name: "unhide", change: func(s Service) error {
+			_, err := s.Unhide(context.Background(), HideCommentDTO{CommentID: "c1", UserID: 7})
+			return err
+		}},
+		{name: "approve", change: func(s Service) error {
+			_, err := s.Approve(context.Background(), ReviewCommentDTO{CommentID: "c1", UserID: 7})
+			return err
+		}},
+		{name: "reject", change: func(s Service) error {
+			return s.Reject(context.Background(), ReviewCommentDTO{CommentID: "c1", UserID: 7})
+		}},
+		{name: "add reaction", change: func(s Service) error {
+			_, err := s.AddReaction(context.Background(), ReactionDTO{CommentID: "c1", UserID: 7, Emoji: "👍"})
+			return err
+		}},
+		{name: "remove reaction", change: func(s Service) error {
+			_, err := s.RemoveReaction(context.Background(), ReactionDTO{CommentID: "c1", UserID: 7, Emoji: "👍"})
+			return err
+		}},
+		{name: "report", change: func(s Service) error {
+			_, err := s.Report(context.Background(), ReportCommentDTO{CommentID: "c1", UserID: 7, Reason: "spam"})
+			return err
+		}},
+		{name: "resolve report", change: func(s Service) error {
+			_, err := s.ResolveReport(context.Background(), ResolveReportDTO{ReportID: "r1", UserID: 7, Action: domain.ReportActionDismiss})
+			return err
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuite(t)
+			bans := mocks.NewBanChecker(t)
+			bans.On("IsRevoked", mock.Anything, int64(7)).Return(true)
+			s.Service.bans = bans
+
+			err := tc.change(s.Service)
+
+			assert.ErrorIs(t, err, domain.ErrUserBanned)
+			s.mockProvider.AssertNotCalled(t, "GetComment", mock.Anything, mock.Anything)
+			s.mockCreator.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_Create_NotBanned(t *testing.T) {
+	s := newSuite(t)
+	bans := mocks.NewBanChecker(t)
+	bans.On("IsRevoked", mock.Anything, int64(7)).Return(false)
+	s.Service.bans = bans
+
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(7)).Return(domain.User{ID: 7}, nil)
+	s.mockCreator.On("CreateComment", mock.Anything, mock.Anything).Return(domain.Comment{ID: "1"}, nil)
+
+	comment, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", UserID: 7, Body: "hello"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", comment.ID)
+}
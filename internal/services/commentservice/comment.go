@@ -11,27 +11,68 @@ import (
 )
 
 type Config struct {
-	Logger       *slog.Logger
-	Provider     Provider
-	Creator      Creator
-	Updater      Updater
-	Deleter      Deleter
-	UserProvider UserProvider
+	Logger         *slog.Logger
+	Provider       Provider
+	Creator        Creator
+	Updater        Updater
+	Deleter        Deleter
+	Reactor        Reactor
+	RevisionKeeper RevisionKeeper
+	UserProvider   UserProvider
+	// ModerationPolicy decides who may moderate the comments of others, only authors can if it is nil
+	ModerationPolicy ModerationPolicy
+	// Publisher is notified after every change of a comment
+	Publisher EventPublisher
+	// Outbox receives the events for the message broker in the transaction of the change, they are not kept if it is nil
+	Outbox Outbox
+	// Transactor runs the changes and the outbox writes in transactions, they run without one if it is nil
+	Transactor Transactor
+	// RateLimiter limits how often comments are created, there is no limit if it is nil
+	RateLimiter RateLimiter
+	// ContentModerator checks the bodies before they are stored, every body is approved if it is nil
+	ContentModerator ContentModerator
+	Reports          ReportStorage
+	ClubResolver     ClubResolver
+	// ReportsToHide is how many distinct reporters hide a comment until a moderator reviews it, 0 disables it
+	ReportsToHide int
+	// Participants resolves the mentions by name, only mentions by id are resolved if it is nil
+	Participants ParticipantFinder
+	// AccessChecker drops the mentions of users who can not view the post, mentions are not resolved if it is nil
+	AccessChecker PostAccessChecker
+	// Bans rejects the changes of banned users, nobody is banned if it is nil
+	Bans BanChecker
 }
 
 type Service struct {
-	log          *slog.Logger
-	provider     Provider
-	creator      Creator
-	updater      Updater
-	deleter      Deleter
-	userProvider UserProvider
+	log            *slog.Logger
+	provider       Provider
+	creator        Creator
+	updater        Updater
+	deleter        Deleter
+	reactor        Reactor
+	revisionKeeper RevisionKeeper
+	userProvider   UserProvider
+	policy         ModerationPolicy
+	publisher      EventPublisher
+	outbox         Outbox
+	transactor     Transactor
+	limiter        RateLimiter
+	moderator      ContentModerator
+	reports        ReportStorage
+	clubResolver   ClubResolver
+	reportsToHide  int
+	participants   ParticipantFinder
+	accessChecker  PostAccessChecker
+	bans           BanChecker
 }
 
 //go:generate mockery --name Provider
 type Provider interface {
 	GetComment(ctx context.Context, commentID string) (domain.Comment, error)
 	ListPostComments(ctx context.Context, postID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error)
+	ListCommentReplies(ctx context.Context, parentID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error)
+	ListPostCommentsChangedSince(ctx context.Context, postID string, after domain.SyncCursor, limit int64) ([]domain.Comment, error)
+	ListPendingComments(ctx context.Context, postID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error)
 }
 
 //go:generate mockery --name Creator
@@ -42,11 +83,20 @@ type Creator interface {
 //go:generate mockery --name Updater
 type Updater interface {
 	UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error)
+	SetCommentHidden(ctx context.Context, commentID string, hiddenBy int64, hiddenAt *time.Time) error
+	SetCommentModeration(ctx context.Context, commentID string, moderation domain.Moderation) error
 }
 
 //go:generate mockery --name Deleter
 type Deleter interface {
-	DeleteComment(ctx context.Context, commentID string) error
+	SoftDeleteComment(ctx context.Context, commentID string, deletedBy int64, deletedAt time.Time) error
+	RestoreComment(ctx context.Context, commentID string) error
+	PurgeDeletedComments(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+//go:generate mockery --name RateLimiter
+type RateLimiter interface {
+	AllowCreate(userID int64, postID string) bool
 }
 
 //go:generate mockery --name UserProvider
@@ -56,12 +106,26 @@ type UserProvider interface {
 
 func New(config Config) Service {
 	return Service{
-		log:          config.Logger,
-		provider:     config.Provider,
-		creator:      config.Creator,
-		updater:      config.Updater,
-		deleter:      config.Deleter,
-		userProvider: config.UserProvider,
+		log:            config.Logger,
+		provider:       config.Provider,
+		creator:        config.Creator,
+		updater:        config.Updater,
+		deleter:        config.Deleter,
+		reactor:        config.Reactor,
+		revisionKeeper: config.RevisionKeeper,
+		userProvider:   config.UserProvider,
+		policy:         config.ModerationPolicy,
+		publisher:      config.Publisher,
+		outbox:         config.Outbox,
+		transactor:     config.Transactor,
+		limiter:        config.RateLimiter,
+		moderator:      config.ContentModerator,
+		reports:        config.Reports,
+		clubResolver:   config.ClubResolver,
+		reportsToHide:  config.ReportsToHide,
+		participants:   config.Participants,
+		accessChecker:  config.AccessChecker,
+		bans:           config.Bans,
 	}
 }
 
@@ -69,14 +133,46 @@ func (s Service) Create(ctx context.Context, comment CreateCommentDTO) (domain.C
 	const op = "service.comment.create"
 	log := s.log.With(slog.String("op", op))
 
+	if err := s.checkBan(ctx, log, comment.UserID); err != nil {
+		return domain.Comment{}, err
+	}
+
+	if s.limiter != nil && !s.limiter.AllowCreate(comment.UserID, comment.PostID) {
+		log.Debug("rate limit exceeded", slog.Int64("user_id", comment.UserID), slog.String("post_id", comment.PostID))
+		return domain.Comment{}, domain.ErrTooManyRequests
+	}
+
+	var depth int32
+	parentID := comment.ParentID
+	if parentID != "" {
+		parent, err := s.provider.GetComment(ctx, parentID)
+		if err != nil {
+			return domain.Comment{}, handleErr(log, op, err)
+		}
+		if parent.PostID != comment.PostID {
+			return domain.Comment{}, domain.ErrParentPostMismatch
+		}
+		if parent.IsDeleted() || parent.IsHidden() || parent.IsPending() {
+			return domain.Comment{}, domain.ErrParentUnavailable
+		}
+		depth = parent.Depth + 1
+		// replies below the deepest level are flattened into the thread of the parent
+		if depth > domain.MaxDepth {
+			parentID = parent.ParentID
+			depth = parent.Depth
+		}
+	}
+
 	user, err := s.userProvider.GetUser(ctx, comment.UserID)
 	if err != nil {
 		return domain.Comment{}, handleErr(log, op, err)
 	}
 
-	createdComment, err := s.creator.CreateComment(ctx, domain.Comment{
+	moderated, err := s.moderate(ctx, domain.Comment{
 		ID:        domain.NewID(),
 		PostID:    comment.PostID,
+		ParentID:  parentID,
+		Depth:     depth,
 		User:      user,
 		Body:      comment.Body,
 		CreatedAt: time.Now(),
@@ -85,6 +181,23 @@ func (s Service) Create(ctx context.Context, comment CreateCommentDTO) (domain.C
 	if err != nil {
 		return domain.Comment{}, handleErr(log, op, err)
 	}
+	moderated.Mentions = s.resolveMentions(ctx, log, moderated)
+
+	var createdComment domain.Comment
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		var err error
+		createdComment, err = s.creator.CreateComment(ctx, moderated)
+		if err != nil {
+			return nil, err
+		}
+
+		created := domain.NewCommentEvent(domain.CommentCreated, maskComment(createdComment), comment.UserID)
+		return append([]domain.CommentEvent{created}, mentionEvents(createdComment, nil)...), nil
+	})
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+	s.record(ctx, createdComment)
 
 	return createdComment, nil
 }
@@ -93,19 +206,61 @@ func (s Service) Update(ctx context.Context, dto UpdateCommentDTO) (domain.Comme
 	const op = "service.comment.update"
 	log := s.log.With(slog.String("op", op))
 
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return domain.Comment{}, err
+	}
+
 	comment, err := s.provider.GetComment(ctx, dto.CommentID)
 	if err != nil {
 		return domain.Comment{}, handleErr(log, op, err)
 	}
 
+	if comment.IsDeleted() {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+
 	if comment.User.ID != dto.UserID {
 		return domain.Comment{}, domain.ErrUnauthorized
 	}
 
-	comment.Body = dto.Body
-	comment.UpdatedAt = time.Now()
+	if comment.Body == dto.Body {
+		return comment, nil
+	}
+
+	edited := comment
+	edited.Body = dto.Body
+	edited.Edited = true
+	edited.UpdatedAt = time.Now()
 
-	updatedComment, err := s.updater.UpdateComment(ctx, comment)
+	edited, err = s.moderate(ctx, edited)
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+	edited.Mentions = s.resolveMentions(ctx, log, edited)
+
+	var updatedComment domain.Comment
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		// keep the previous version so the original text can still be reviewed
+		err := s.revisionKeeper.CreateRevision(ctx, domain.Revision{
+			ID:        domain.NewID(),
+			CommentID: comment.ID,
+			Body:      comment.Body,
+			EditorID:  dto.UserID,
+			EditedAt:  time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		updatedComment, err = s.updater.UpdateComment(ctx, edited)
+		if err != nil {
+			return nil, err
+		}
+
+		updated := domain.NewCommentEvent(domain.CommentUpdated, maskComment(updatedComment), dto.UserID)
+		// only the users mentioned by the edit are notified, the others already were
+		return append([]domain.CommentEvent{updated}, mentionEvents(updatedComment, comment.Mentions)...), nil
+	})
 	if err != nil {
 		return domain.Comment{}, handleErr(log, op, err)
 	}
@@ -117,16 +272,36 @@ func (s Service) Delete(ctx context.Context, dto DeleteCommentDTO) error {
 	const op = "service.comment.delete"
 	log := s.log.With(slog.String("op", op))
 
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return err
+	}
+
 	comment, err := s.provider.GetComment(ctx, dto.CommentID)
 	if err != nil {
 		return handleErr(log, op, err)
 	}
+	if comment.IsDeleted() {
+		return domain.ErrCommentNotFound
+	}
 
 	if comment.User.ID != dto.UserID {
-		return domain.ErrUnauthorized
+		err = s.authorizeModerator(ctx, dto.UserID, comment.PostID)
+		if err != nil {
+			return handleErr(log, op, err)
+		}
 	}
 
-	err = s.deleter.DeleteComment(ctx, dto.CommentID)
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		deletedAt := time.Now()
+		err := s.deleter.SoftDeleteComment(ctx, dto.CommentID, dto.UserID, deletedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.DeletedAt = &deletedAt
+		comment.DeletedBy = dto.UserID
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentDeleted, comment.Tombstone(), dto.UserID)}, nil
+	})
 	if err != nil {
 		return handleErr(log, op, err)
 	}
@@ -134,6 +309,62 @@ func (s Service) Delete(ctx context.Context, dto DeleteCommentDTO) error {
 	return nil
 }
 
+// Restore brings back a soft deleted comment that has not been purged yet
+func (s Service) Restore(ctx context.Context, dto RestoreCommentDTO) (domain.Comment, error) {
+	const op = "service.comment.restore"
+	log := s.log.With(slog.String("op", op))
+
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment, err := s.provider.GetComment(ctx, dto.CommentID)
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	if !comment.IsDeleted() {
+		return domain.Comment{}, domain.ErrCommentNotDeleted
+	}
+
+	// authors can not restore comments removed by a moderator
+	if comment.User.ID != dto.UserID || comment.DeletedBy != dto.UserID {
+		err = s.authorizeModerator(ctx, dto.UserID, comment.PostID)
+		if err != nil {
+			return domain.Comment{}, handleErr(log, op, err)
+		}
+	}
+
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		err := s.deleter.RestoreComment(ctx, dto.CommentID)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.DeletedAt = nil
+		comment.DeletedBy = 0
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentUpdated, maskComment(comment), dto.UserID)}, nil
+	})
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	return comment, nil
+}
+
+// PurgeDeleted hard deletes the comments soft deleted more than retention ago and returns how many were purged
+func (s Service) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error) {
+	const op = "service.comment.purge_deleted"
+	log := s.log.With(slog.String("op", op))
+
+	purged, err := s.deleter.PurgeDeletedComments(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return purged, handleErr(log, op, err)
+	}
+
+	return purged, nil
+}
+
 func (s Service) GetByID(ctx context.Context, id string) (domain.Comment, error) {
 	const op = "service.comment.get_by_id"
 	log := s.log.With(slog.String("op", op))
@@ -143,7 +374,14 @@ func (s Service) GetByID(ctx context.Context, id string) (domain.Comment, error)
 		return domain.Comment{}, handleErr(log, op, err)
 	}
 
-	return comment, nil
+	if comment.IsDeleted() {
+		if comment.ReplyCount == 0 {
+			return domain.Comment{}, domain.ErrCommentNotFound
+		}
+		return comment.Tombstone(), nil
+	}
+
+	return maskComment(comment), nil
 }
 
 func (s Service) ListByPostID(ctx context.Context, postID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error) {
@@ -155,16 +393,75 @@ func (s Service) ListByPostID(ctx context.Context, postID string, filter domain.
 		return nil, domain.PaginationMetadata{}, handleErr(log, op, err)
 	}
 
-	return comments, metadata, nil
+	return maskComments(comments), metadata, nil
+}
+
+// ListReplies returns the direct replies of the comment
+func (s Service) ListReplies(ctx context.Context, commentID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error) {
+	const op = "service.comment.list_replies"
+	log := s.log.With(slog.String("op", op))
+
+	_, err := s.provider.GetComment(ctx, commentID)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, handleErr(log, op, err)
+	}
+
+	replies, metadata, err := s.provider.ListCommentReplies(ctx, commentID, filter)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, handleErr(log, op, err)
+	}
+
+	return maskComments(replies), metadata, nil
+}
+
+// maskComments hides the content of deleted, hidden and pending comments, the storage only lists the deleted ones that have replies
+func maskComments(comments []domain.Comment) []domain.Comment {
+	for i, comment := range comments {
+		comments[i] = maskComment(comment)
+	}
+	return comments
+}
+
+// maskComment returns the comment as it is shown to users
+func maskComment(comment domain.Comment) domain.Comment {
+	switch {
+	case comment.IsDeleted():
+		return comment.Tombstone()
+	case comment.IsHidden():
+		return comment.Masked()
+	case comment.IsPending():
+		return comment.Pending()
+	default:
+		return comment
+	}
 }
 
 func handleErr(log *slog.Logger, op string, err error) error {
 	switch {
 	case errors.Is(err, domain.ErrInvalidID):
 		return err
-	case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrCommentNotFound):
+	case errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrCommentNotFound),
+		errors.Is(err, domain.ErrCommentNotDeleted),
+		errors.Is(err, domain.ErrCommentNotPending):
+		return err
+	case errors.Is(err, domain.ErrUserBanned):
+		return err
+	case errors.Is(err, domain.ErrInvalidArg),
+		errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrParentPostMismatch),
+		errors.Is(err, domain.ErrParentUnavailable),
+		errors.Is(err, domain.ErrInvalidReaction):
+		return err
+	case errors.Is(err, domain.ErrUnauthorized), errors.Is(err, domain.ErrPostNotFound), errors.Is(err, domain.ErrClubNotFound):
+		return err
+	case errors.Is(err, domain.ErrReportNotFound),
+		errors.Is(err, domain.ErrAlreadyReported),
+		errors.Is(err, domain.ErrOwnCommentReport),
+		errors.Is(err, domain.ErrReportResolved),
+		errors.Is(err, domain.ErrInvalidReportAction):
 		return err
-	case errors.Is(err, domain.ErrInvalidArg):
+	case errors.Is(err, domain.ErrTooManyRequests), errors.Is(err, domain.ErrCommentRejected):
 		return err
 	default:
 		log.Error(op, logger.Err(err))
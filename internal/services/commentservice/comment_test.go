@@ -13,29 +13,37 @@ import (
 )
 
 type Suite struct {
-	Service          Service
-	mockProvider     *mocks.Provider
-	mockCreator      *mocks.Creator
-	mockUpdater      *mocks.Updater
-	mockDeleter      *mocks.Deleter
-	mockUserProvider *mocks.UserProvider
+	Service            Service
+	mockProvider       *mocks.Provider
+	mockCreator        *mocks.Creator
+	mockUpdater        *mocks.Updater
+	mockDeleter        *mocks.Deleter
+	mockReactor        *mocks.Reactor
+	mockRevisionKeeper *mocks.RevisionKeeper
+	mockUserProvider   *mocks.UserProvider
+	mockPolicy         *mocks.ModerationPolicy
 }
 
 func newSuite(t *testing.T) *Suite {
 	s := &Suite{
-		mockProvider:     mocks.NewProvider(t),
-		mockCreator:      mocks.NewCreator(t),
-		mockUpdater:      mocks.NewUpdater(t),
-		mockDeleter:      mocks.NewDeleter(t),
-		mockUserProvider: mocks.NewUserProvider(t),
+		mockProvider:       mocks.NewProvider(t),
+		mockCreator:        mocks.NewCreator(t),
+		mockUpdater:        mocks.NewUpdater(t),
+		mockDeleter:        mocks.NewDeleter(t),
+		mockReactor:        mocks.NewReactor(t),
+		mockRevisionKeeper: mocks.NewRevisionKeeper(t),
+		mockUserProvider:   mocks.NewUserProvider(t),
+		mockPolicy:         mocks.NewModerationPolicy(t),
 	}
 	s.Service = New(Config{
-		Logger:       logger.Plug(),
-		Provider:     s.mockProvider,
-		Creator:      s.mockCreator,
-		Updater:      s.mockUpdater,
-		Deleter:      s.mockDeleter,
-		UserProvider: s.mockUserProvider,
+		Logger:         logger.Plug(),
+		Provider:       s.mockProvider,
+		Creator:        s.mockCreator,
+		Updater:        s.mockUpdater,
+		Deleter:        s.mockDeleter,
+		Reactor:        s.mockReactor,
+		RevisionKeeper: s.mockRevisionKeeper,
+		UserProvider:   s.mockUserProvider,
 	})
 	return s
 }
@@ -94,6 +102,118 @@ func TestService_Create_FailPath(t *testing.T) {
 	}
 }
 
+func TestService_Create_RateLimited(t *testing.T) {
+	s := newSuite(t)
+	limiter := mocks.NewRateLimiter(t)
+	s.Service.limiter = limiter
+
+	limiter.On("AllowCreate", int64(7), "p1").Return(false)
+
+	_, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", UserID: 7, Body: "hello"})
+
+	assert.ErrorIs(t, err, domain.ErrTooManyRequests)
+	s.mockUserProvider.AssertNotCalled(t, "GetUser", mock.Anything, mock.Anything)
+	s.mockCreator.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything)
+}
+
+func TestService_Create_RateLimitAllowed(t *testing.T) {
+	s := newSuite(t)
+	limiter := mocks.NewRateLimiter(t)
+	s.Service.limiter = limiter
+
+	limiter.On("AllowCreate", int64(7), "p1").Return(true)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(7)).Return(domain.User{ID: 7}, nil)
+	s.mockCreator.On("CreateComment", mock.Anything, mock.Anything).Return(domain.Comment{ID: "1"}, nil)
+
+	comment, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", UserID: 7, Body: "hello"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", comment.ID)
+}
+
+func TestService_Create_Reply(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+	defer s.mockCreator.AssertExpectations(t)
+	defer s.mockUserProvider.AssertExpectations(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "parent").Return(domain.Comment{ID: "parent", PostID: "post", Depth: 1}, nil)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	s.mockCreator.On("CreateComment", mock.Anything, mock.AnythingOfType("domain.Comment")).Return(func(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+		return comment, nil
+	})
+
+	comment, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "post", ParentID: "parent", UserID: 1, Body: "reply"})
+	assert.Nil(t, err)
+	assert.Equal(t, "parent", comment.ParentID)
+	assert.Equal(t, int32(2), comment.Depth)
+}
+
+func TestService_Create_Reply_MaxDepth(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+	defer s.mockCreator.AssertExpectations(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "parent").Return(domain.Comment{ID: "parent", ParentID: "grandparent", PostID: "post", Depth: domain.MaxDepth}, nil)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	s.mockCreator.On("CreateComment", mock.Anything, mock.AnythingOfType("domain.Comment")).Return(func(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+		return comment, nil
+	})
+
+	comment, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "post", ParentID: "parent", UserID: 1, Body: "reply"})
+	assert.Nil(t, err)
+	assert.Equal(t, "grandparent", comment.ParentID)
+	assert.Equal(t, int32(domain.MaxDepth), comment.Depth)
+}
+
+func TestService_Create_Reply_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		parent        domain.Comment
+		onGetComment  error
+		expectedError error
+	}{
+		{
+			name:          "parent not found",
+			onGetComment:  domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "parent belongs to another post",
+			parent:        domain.Comment{ID: "parent", PostID: "another post"},
+			expectedError: domain.ErrParentPostMismatch,
+		},
+		{
+			name:          "parent deleted",
+			parent:        domain.Comment{ID: "parent", PostID: "post", DeletedAt: &time.Time{}},
+			expectedError: domain.ErrParentUnavailable,
+		},
+		{
+			name:          "parent hidden",
+			parent:        domain.Comment{ID: "parent", PostID: "post", HiddenAt: &time.Time{}},
+			expectedError: domain.ErrParentUnavailable,
+		},
+		{
+			name:          "parent pending",
+			parent:        domain.Comment{ID: "parent", PostID: "post", Moderation: domain.Moderation{Status: domain.ModerationPending}},
+			expectedError: domain.ErrParentUnavailable,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuite(t)
+			defer s.mockProvider.AssertExpectations(t)
+
+			s.mockProvider.On("GetComment", mock.Anything, "parent").Return(tc.parent, tc.onGetComment)
+
+			_, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "post", ParentID: "parent", UserID: 1})
+			assert.ErrorIs(t, err, tc.expectedError)
+			s.mockCreator.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestService_Update(t *testing.T) {
 	baseComment := domain.Comment{
 		ID:     "1",
@@ -134,7 +254,12 @@ func TestService_Update(t *testing.T) {
 			defer s.mockProvider.AssertExpectations(t)
 			defer s.mockUpdater.AssertExpectations(t)
 
+			defer s.mockRevisionKeeper.AssertExpectations(t)
+
 			s.mockProvider.On("GetComment", mock.Anything, "1").Return(baseComment, nil)
+			s.mockRevisionKeeper.On("CreateRevision", mock.Anything, mock.MatchedBy(func(revision domain.Revision) bool {
+				return revision.CommentID == baseComment.ID && revision.Body == baseComment.Body && revision.EditorID == tc.dto.UserID
+			})).Return(nil)
 			s.mockUpdater.On("UpdateComment", mock.Anything, mock.AnythingOfType("domain.Comment")).Return(func(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
 				return comment, nil
 			})
@@ -143,11 +268,25 @@ func TestService_Update(t *testing.T) {
 			assert.Nil(t, err)
 			assert.NotNil(t, comment)
 			assert.Equal(t, tc.dto.Body, comment.Body)
+			assert.True(t, comment.Edited)
 			assert.NotEqual(t, baseComment.UpdatedAt, comment.UpdatedAt)
 		})
 	}
 }
 
+func TestService_Update_SameBody(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", Body: "body", User: domain.User{ID: 1}}, nil)
+
+	comment, err := s.Service.Update(context.Background(), UpdateCommentDTO{CommentID: "1", UserID: 1, Body: "body"})
+	assert.Nil(t, err)
+	assert.False(t, comment.Edited)
+	s.mockRevisionKeeper.AssertNotCalled(t, "CreateRevision", mock.Anything, mock.Anything)
+	s.mockUpdater.AssertNotCalled(t, "UpdateComment", mock.Anything, mock.Anything)
+}
+
 func TestService_Update_FailPath(t *testing.T) {
 
 	baseComment := domain.Comment{
@@ -207,6 +346,7 @@ func TestService_Update_FailPath(t *testing.T) {
 			s.mockProvider.On("GetComment", mock.Anything, "1").Return(baseComment, tc.onGetComment)
 
 			if tc.onGetComment == nil && tc.expectedError != domain.ErrUnauthorized {
+				s.mockRevisionKeeper.On("CreateRevision", mock.Anything, mock.AnythingOfType("domain.Revision")).Return(nil)
 				s.mockUpdater.On("UpdateComment", mock.Anything, mock.AnythingOfType("domain.Comment")).Return(func(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
 					return comment, tc.onUpdate
 				})
@@ -224,7 +364,7 @@ func TestService_Delete(t *testing.T) {
 	defer s.mockDeleter.AssertExpectations(t)
 
 	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{User: domain.User{ID: 1}}, nil)
-	s.mockDeleter.On("DeleteComment", mock.Anything, "1").Return(nil)
+	s.mockDeleter.On("SoftDeleteComment", mock.Anything, "1", int64(1), mock.AnythingOfType("time.Time")).Return(nil)
 
 	err := s.Service.Delete(context.Background(), DeleteCommentDTO{
 		UserID:    1,
@@ -234,10 +374,12 @@ func TestService_Delete(t *testing.T) {
 }
 
 func TestService_Delete_FailPath(t *testing.T) {
+	deletedAt := time.Now()
 
 	tests := []struct {
 		name          string
 		dto           DeleteCommentDTO
+		comment       domain.Comment
 		onGetComment  error
 		onDelete      error
 		expectedError error
@@ -270,6 +412,12 @@ func TestService_Delete_FailPath(t *testing.T) {
 			onDelete:      nil,
 			expectedError: domain.ErrUnauthorized,
 		},
+		{
+			name:          "already deleted",
+			dto:           DeleteCommentDTO{UserID: 0, CommentID: "1"},
+			comment:       domain.Comment{ID: "1", DeletedAt: &deletedAt},
+			expectedError: domain.ErrCommentNotFound,
+		},
 	}
 
 	for _, tc := range tests {
@@ -278,10 +426,10 @@ func TestService_Delete_FailPath(t *testing.T) {
 			defer s.mockProvider.AssertExpectations(t)
 			defer s.mockDeleter.AssertExpectations(t)
 
-			s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{}, tc.onGetComment)
+			s.mockProvider.On("GetComment", mock.Anything, "1").Return(tc.comment, tc.onGetComment)
 
-			if tc.onGetComment == nil && tc.expectedError != domain.ErrUnauthorized {
-				s.mockDeleter.On("DeleteComment", mock.Anything, "1").Return(tc.onDelete)
+			if tc.onGetComment == nil && tc.expectedError != domain.ErrUnauthorized && !tc.comment.IsDeleted() {
+				s.mockDeleter.On("SoftDeleteComment", mock.Anything, "1", tc.dto.UserID, mock.AnythingOfType("time.Time")).Return(tc.onDelete)
 			}
 
 			err := s.Service.Delete(context.Background(), tc.dto)
@@ -301,6 +449,38 @@ func TestService_GetByID(t *testing.T) {
 	assert.NotNil(t, comment)
 }
 
+func TestService_GetByID_Deleted(t *testing.T) {
+	deletedAt := time.Now()
+
+	t.Run("with replies returns tombstone", func(t *testing.T) {
+		s := newSuite(t)
+		defer s.mockProvider.AssertExpectations(t)
+
+		s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{
+			ID:         "1",
+			Body:       "body",
+			User:       domain.User{ID: 1},
+			ReplyCount: 1,
+			DeletedAt:  &deletedAt,
+		}, nil)
+
+		comment, err := s.Service.GetByID(context.Background(), "1")
+		assert.Nil(t, err)
+		assert.Equal(t, domain.TombstoneBody, comment.Body)
+		assert.Equal(t, domain.User{}, comment.User)
+	})
+
+	t.Run("without replies is not found", func(t *testing.T) {
+		s := newSuite(t)
+		defer s.mockProvider.AssertExpectations(t)
+
+		s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", DeletedAt: &deletedAt}, nil)
+
+		_, err := s.Service.GetByID(context.Background(), "1")
+		assert.ErrorIs(t, err, domain.ErrCommentNotFound)
+	})
+}
+
 func TestService_GetByID_FailPath(t *testing.T) {
 
 	tests := []struct {
@@ -359,6 +539,23 @@ func TestService_ListByPostID(t *testing.T) {
 	assert.Equal(t, expectedMetadata, metadata)
 }
 
+func TestService_ListByPostID_Tombstones(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+
+	deletedAt := time.Now()
+	s.mockProvider.On("ListPostComments", mock.Anything, "1", domain.Filter{}).Return([]domain.Comment{
+		{ID: "1", Body: "Comment 1"},
+		{ID: "2", Body: "Comment 2", ReplyCount: 3, DeletedAt: &deletedAt},
+	}, domain.PaginationMetadata{}, nil)
+
+	comments, _, err := s.Service.ListByPostID(context.Background(), "1", domain.Filter{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Comment 1", comments[0].Body)
+	assert.Equal(t, domain.TombstoneBody, comments[1].Body)
+	assert.Equal(t, int32(3), comments[1].ReplyCount)
+}
+
 func TestService_ListByPostID_FailPath(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -384,3 +581,150 @@ func TestService_ListByPostID_FailPath(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ListReplies(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+
+	expectedReplies := []domain.Comment{
+		{ID: "2", ParentID: "1", Depth: 1},
+		{ID: "3", ParentID: "1", Depth: 1},
+	}
+	expectedMetadata := domain.PaginationMetadata{TotalRecords: 2, PageSize: 10, CurrentPage: 1, FirstPage: 1, LastPage: 1}
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", ReplyCount: 2}, nil)
+	s.mockProvider.On("ListCommentReplies", mock.Anything, "1", domain.Filter{}).Return(expectedReplies, expectedMetadata, nil)
+
+	replies, metadata, err := s.Service.ListReplies(context.Background(), "1", domain.Filter{})
+	assert.Nil(t, err)
+	assert.Equal(t, expectedReplies, replies)
+	assert.Equal(t, expectedMetadata, metadata)
+}
+
+func TestService_ListReplies_FailPath(t *testing.T) {
+	tests := []struct {
+		name                 string
+		onGetComment         error
+		onListCommentReplies error
+		expectedError        error
+	}{
+		{
+			name:          "parent not found",
+			onGetComment:  domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:                 "unexpected error",
+			onListCommentReplies: assert.AnError,
+			expectedError:        domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuite(t)
+			defer s.mockProvider.AssertExpectations(t)
+
+			s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{}, tc.onGetComment)
+			if tc.onGetComment == nil {
+				s.mockProvider.On("ListCommentReplies", mock.Anything, "1", domain.Filter{}).Return(nil, domain.PaginationMetadata{}, tc.onListCommentReplies)
+			}
+
+			_, _, err := s.Service.ListReplies(context.Background(), "1", domain.Filter{})
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestService_Restore(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+	defer s.mockDeleter.AssertExpectations(t)
+
+	deletedAt := time.Now()
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", User: domain.User{ID: 1}, DeletedAt: &deletedAt, DeletedBy: 1}, nil)
+	s.mockDeleter.On("RestoreComment", mock.Anything, "1").Return(nil)
+
+	comment, err := s.Service.Restore(context.Background(), RestoreCommentDTO{UserID: 1, CommentID: "1"})
+	assert.Nil(t, err)
+	assert.False(t, comment.IsDeleted())
+	assert.Zero(t, comment.DeletedBy)
+}
+
+func TestService_Restore_FailPath(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		dto           RestoreCommentDTO
+		comment       domain.Comment
+		onGetComment  error
+		onRestore     error
+		expectedError error
+	}{
+		{
+			name:          "comment not found",
+			dto:           RestoreCommentDTO{UserID: 1, CommentID: "1"},
+			onGetComment:  domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "comment not deleted",
+			dto:           RestoreCommentDTO{UserID: 1, CommentID: "1"},
+			comment:       domain.Comment{ID: "1", User: domain.User{ID: 1}},
+			expectedError: domain.ErrCommentNotDeleted,
+		},
+		{
+			name:          "unauthorized",
+			dto:           RestoreCommentDTO{UserID: 2, CommentID: "1"},
+			comment:       domain.Comment{ID: "1", User: domain.User{ID: 1}, DeletedAt: &deletedAt},
+			expectedError: domain.ErrUnauthorized,
+		},
+		{
+			name:          "unexpected error",
+			dto:           RestoreCommentDTO{UserID: 1, CommentID: "1"},
+			comment:       domain.Comment{ID: "1", User: domain.User{ID: 1}, DeletedAt: &deletedAt, DeletedBy: 1},
+			onRestore:     assert.AnError,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuite(t)
+			defer s.mockProvider.AssertExpectations(t)
+			defer s.mockDeleter.AssertExpectations(t)
+
+			s.mockProvider.On("GetComment", mock.Anything, "1").Return(tc.comment, tc.onGetComment)
+			if tc.onRestore != nil {
+				s.mockDeleter.On("RestoreComment", mock.Anything, "1").Return(tc.onRestore)
+			}
+
+			_, err := s.Service.Restore(context.Background(), tc.dto)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestService_PurgeDeleted(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockDeleter.AssertExpectations(t)
+
+	s.mockDeleter.On("PurgeDeletedComments", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= time.Hour
+	})).Return(int64(5), nil)
+
+	purged, err := s.Service.PurgeDeleted(context.Background(), time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), purged)
+}
+
+func TestService_PurgeDeleted_FailPath(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockDeleter.AssertExpectations(t)
+
+	s.mockDeleter.On("PurgeDeletedComments", mock.Anything, mock.Anything).Return(int64(0), assert.AnError)
+
+	_, err := s.Service.PurgeDeleted(context.Background(), time.Hour)
+	assert.ErrorIs(t, err, domain.ErrInternal)
+}
@@ -1,9 +1,13 @@
 package commentservice
 
+import "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+
 type CreateCommentDTO struct {
 	PostID string `json:"post_id"`
-	Body   string `json:"body"`
-	UserID int64  `json:"user_id"`
+	// ParentID is optional, set it to reply to another comment of the same post
+	ParentID string `json:"parent_id"`
+	Body     string `json:"body"`
+	UserID   int64  `json:"user_id"`
 }
 
 type UpdateCommentDTO struct {
@@ -16,3 +20,60 @@ type DeleteCommentDTO struct {
 	UserID    int64  `json:"user_id"`
 	CommentID string `json:"comment_id"`
 }
+
+type RestoreCommentDTO struct {
+	UserID    int64  `json:"user_id"`
+	CommentID string `json:"comment_id"`
+}
+
+type ListRevisionsDTO struct {
+	UserID    int64  `json:"user_id"`
+	CommentID string `json:"comment_id"`
+}
+
+type ReactionDTO struct {
+	UserID    int64  `json:"user_id"`
+	CommentID string `json:"comment_id"`
+	Emoji     string `json:"emoji"`
+}
+
+type HideCommentDTO struct {
+	UserID    int64  `json:"user_id"`
+	CommentID string `json:"comment_id"`
+}
+
+// ReviewCommentDTO is the decision of a moderator on a comment held by the moderation pipeline
+type ReviewCommentDTO struct {
+	UserID    int64  `json:"user_id"`
+	CommentID string `json:"comment_id"`
+}
+
+// ListPendingDTO selects the comments of the post that wait for a moderator
+type ListPendingDTO struct {
+	UserID   int64  `json:"user_id"`
+	PostID   string `json:"post_id"`
+	Page     int32  `json:"page"`
+	PageSize int32  `json:"page_size"`
+}
+
+type ReportCommentDTO struct {
+	UserID    int64  `json:"user_id"`
+	CommentID string `json:"comment_id"`
+	// Reason is optional, it is shown to the moderators
+	Reason string `json:"reason"`
+}
+
+// ListReportsDTO selects the open reports of either a club or a post
+type ListReportsDTO struct {
+	UserID   int64  `json:"user_id"`
+	ClubID   int64  `json:"club_id"`
+	PostID   string `json:"post_id"`
+	Page     int32  `json:"page"`
+	PageSize int32  `json:"page_size"`
+}
+
+type ResolveReportDTO struct {
+	UserID   int64               `json:"user_id"`
+	ReportID string              `json:"report_id"`
+	Action   domain.ReportAction `json:"action"`
+}
@@ -0,0 +1,71 @@
+package commentservice
+
+import (
+	"context"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+// EventPublisher receives the events of the stored comment changes, e.g. to notify websocket subscribers
+//
+//go:generate mockery --name EventPublisher
+type EventPublisher interface {
+	PublishCommentEvent(ctx context.Context, event domain.CommentEvent)
+}
+
+// Outbox keeps the events for the message broker, they are relayed to the broker after the transaction is committed
+//
+//go:generate mockery --name Outbox
+type Outbox interface {
+	AddCommentEvents(ctx context.Context, events ...domain.CommentEvent) error
+}
+
+// Transactor runs fn in a transaction, the storage calls made with the context passed to fn are part of it
+//
+//go:generate mockery --name Transactor
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// store runs the change and adds the events it returns to the outbox in one transaction,
+// so the message broker receives the events of exactly the stored changes.
+// The events are published once the transaction is committed.
+func (s Service) store(ctx context.Context, change func(ctx context.Context) ([]domain.CommentEvent, error)) error {
+	var events []domain.CommentEvent
+	run := func(ctx context.Context) error {
+		var err error
+		events, err = change(ctx)
+		if err != nil {
+			return err
+		}
+
+		if s.outbox == nil || len(events) == 0 {
+			return nil
+		}
+		return s.outbox.AddCommentEvents(ctx, events...)
+	}
+
+	var err error
+	if s.transactor != nil {
+		err = s.transactor.WithTransaction(ctx, run)
+	} else {
+		err = run(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		s.publish(ctx, event)
+	}
+
+	return nil
+}
+
+// publish emits the event if a publisher is configured
+func (s Service) publish(ctx context.Context, event domain.CommentEvent) {
+	if s.publisher == nil {
+		return
+	}
+	s.publisher.PublishCommentEvent(ctx, event)
+}
@@ -0,0 +1,196 @@
+package commentservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newPublisherSuite returns the suite with the event publisher configured
+func newPublisherSuite(t *testing.T) (*Suite, *mocks.EventPublisher) {
+	s := newSuite(t)
+	publisher := mocks.NewEventPublisher(t)
+	s.Service = New(Config{
+		Logger:           logger.Plug(),
+		Provider:         s.mockProvider,
+		Creator:          s.mockCreator,
+		Updater:          s.mockUpdater,
+		Deleter:          s.mockDeleter,
+		Reactor:          s.mockReactor,
+		RevisionKeeper:   s.mockRevisionKeeper,
+		UserProvider:     s.mockUserProvider,
+		ModerationPolicy: s.mockPolicy,
+		Publisher:        publisher,
+	})
+	return s, publisher
+}
+
+func TestService_Create_PublishesEvent(t *testing.T) {
+	s, publisher := newPublisherSuite(t)
+
+	created := domain.Comment{ID: "1", PostID: "p1", User: domain.User{ID: 1}, Body: "hello"}
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	s.mockCreator.On("CreateComment", mock.Anything, mock.Anything).Return(created, nil)
+	publisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(event domain.CommentEvent) bool {
+		return event.Type == domain.CommentCreated && event.Comment.ID == "1" && event.ActorID == 1 && !event.OccurredAt.IsZero()
+	})).Once()
+
+	_, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", Body: "hello", UserID: 1})
+	assert.Nil(t, err)
+}
+
+func TestService_Delete_PublishesTombstone(t *testing.T) {
+	s, publisher := newPublisherSuite(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", PostID: "p1", User: domain.User{ID: 1}, Body: "hello"}, nil)
+	s.mockDeleter.On("SoftDeleteComment", mock.Anything, "1", int64(1), mock.Anything).Return(nil)
+	publisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(event domain.CommentEvent) bool {
+		return event.Type == domain.CommentDeleted && event.Comment.IsDeleted() && event.Comment.Body != "hello"
+	})).Once()
+
+	err := s.Service.Delete(context.Background(), DeleteCommentDTO{UserID: 1, CommentID: "1"})
+	assert.Nil(t, err)
+}
+
+func TestService_AddReaction_PublishesEvent(t *testing.T) {
+	s, publisher := newPublisherSuite(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", PostID: "p1"}, nil)
+	s.mockReactor.On("AddReaction", mock.Anything, mock.Anything).
+		Return(domain.Comment{ID: "1", PostID: "p1", Reactions: map[string]int32{"👍": 1}}, nil)
+	publisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(event domain.CommentEvent) bool {
+		return event.Type == domain.CommentReactionsUpdated && event.Comment.Reactions["👍"] == 1
+	})).Once()
+
+	_, err := s.Service.AddReaction(context.Background(), ReactionDTO{CommentID: "1", Emoji: "👍", UserID: 1})
+	assert.Nil(t, err)
+}
+
+func TestService_Update_FailPath_DoesNotPublish(t *testing.T) {
+	s, publisher := newPublisherSuite(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", User: domain.User{ID: 1}}, nil)
+
+	_, err := s.Service.Update(context.Background(), UpdateCommentDTO{CommentID: "1", Body: "edited", UserID: 2})
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	publisher.AssertNotCalled(t, "PublishCommentEvent", mock.Anything, mock.Anything)
+}
+
+// newOutboxSuite returns the suite with the outbox written in the transactions of the changes
+func newOutboxSuite(t *testing.T) (*Suite, *mocks.EventPublisher, *mocks.Outbox, *mocks.Transactor) {
+	s := newSuite(t)
+	publisher := mocks.NewEventPublisher(t)
+	outbox := mocks.NewOutbox(t)
+	transactor := mocks.NewTransactor(t)
+	s.Service = New(Config{
+		Logger:           logger.Plug(),
+		Provider:         s.mockProvider,
+		Creator:          s.mockCreator,
+		Updater:          s.mockUpdater,
+		Deleter:          s.mockDeleter,
+		Reactor:          s.mockReactor,
+		RevisionKeeper:   s.mockRevisionKeeper,
+		UserProvider:     s.mockUserProvider,
+		ModerationPolicy: s.mockPolicy,
+		Publisher:        publisher,
+		Outbox:           outbox,
+		Transactor:       transactor,
+	})
+	return s, publisher, outbox, transactor
+}
+
+type txKey struct{}
+
+// runInTransaction makes the transactor call fn with a context that marks the transaction
+func runInTransaction(transactor *mocks.Transactor) *mock.Call {
+	return transactor.On("WithTransaction", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(context.WithValue(ctx, txKey{}, true))
+		})
+}
+
+func inTransaction(ctx context.Context) bool {
+	return ctx.Value(txKey{}) != nil
+}
+
+func TestService_Create_WritesOutboxInTransaction(t *testing.T) {
+	s, publisher, outbox, transactor := newOutboxSuite(t)
+
+	created := domain.Comment{ID: "1", PostID: "p1", User: domain.User{ID: 1}, Body: "hello"}
+	runInTransaction(transactor).Once()
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	s.mockCreator.On("CreateComment", mock.MatchedBy(inTransaction), mock.Anything).Return(created, nil)
+	outbox.On("AddCommentEvents", mock.MatchedBy(inTransaction), mock.MatchedBy(func(event domain.CommentEvent) bool {
+		return event.Type == domain.CommentCreated && event.Comment.ID == "1"
+	})).Return(nil).Once()
+	publisher.On("PublishCommentEvent", mock.MatchedBy(func(ctx context.Context) bool {
+		return !inTransaction(ctx)
+	}), mock.MatchedBy(func(event domain.CommentEvent) bool {
+		return event.Type == domain.CommentCreated
+	})).Once()
+
+	_, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", Body: "hello", UserID: 1})
+	assert.NoError(t, err)
+}
+
+func TestService_ResolveReport_WritesOutboxInTransaction(t *testing.T) {
+	s, publisher, outbox, transactor := newOutboxSuite(t)
+	reports := mocks.NewReportStorage(t)
+	s.Service.reports = reports
+
+	reports.On("GetReport", mock.Anything, "r1").
+		Return(domain.Report{ID: "r1", CommentID: "1", PostID: "p1", Status: domain.ReportOpen}, nil)
+	s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(true, nil)
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", PostID: "p1", User: domain.User{ID: 1}}, nil)
+	runInTransaction(transactor).Once()
+	s.mockDeleter.On("SoftDeleteComment", mock.MatchedBy(inTransaction), "1", int64(2), mock.Anything).Return(nil)
+	reports.On("ResolveReports", mock.MatchedBy(inTransaction), "1", domain.ReportDeleted, int64(2), mock.Anything).Return(int64(1), nil)
+	outbox.On("AddCommentEvents", mock.MatchedBy(inTransaction), mock.MatchedBy(func(event domain.CommentEvent) bool {
+		return event.Type == domain.CommentDeleted
+	})).Return(nil).Once()
+	publisher.On("PublishCommentEvent", mock.Anything, mock.Anything).Once()
+
+	_, err := s.Service.ResolveReport(context.Background(), ResolveReportDTO{ReportID: "r1", Action: domain.ReportActionDelete, UserID: 2})
+	assert.NoError(t, err)
+}
+
+func TestService_Store_FailPath(t *testing.T) {
+	testCases := []struct {
+		name  string
+		setup func(s *Suite, outbox *mocks.Outbox)
+	}{
+		{
+			name: "change fails",
+			setup: func(s *Suite, outbox *mocks.Outbox) {
+				s.mockDeleter.On("SoftDeleteComment", mock.Anything, "1", int64(1), mock.Anything).Return(assert.AnError)
+			},
+		},
+		{
+			name: "outbox fails",
+			setup: func(s *Suite, outbox *mocks.Outbox) {
+				s.mockDeleter.On("SoftDeleteComment", mock.Anything, "1", int64(1), mock.Anything).Return(nil)
+				outbox.On("AddCommentEvents", mock.Anything, mock.Anything).Return(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, publisher, outbox, transactor := newOutboxSuite(t)
+
+			s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", PostID: "p1", User: domain.User{ID: 1}}, nil)
+			runInTransaction(transactor)
+			tc.setup(s, outbox)
+
+			err := s.Service.Delete(context.Background(), DeleteCommentDTO{UserID: 1, CommentID: "1"})
+			assert.ErrorIs(t, err, domain.ErrInternal)
+			// the events of a rolled back change are not published
+			publisher.AssertNotCalled(t, "PublishCommentEvent", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package commentservice
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+)
+
+// ParticipantFinder finds the users mentioned by name among the authors of the comments of the post
+//
+//go:generate mockery --name ParticipantFinder
+type ParticipantFinder interface {
+	FindPostUsersByName(ctx context.Context, postID string, firstName, lastName string) ([]domain.User, error)
+}
+
+// PostAccessChecker decides whether a mentioned user may read the post of the comment
+//
+//go:generate mockery --name PostAccessChecker
+type PostAccessChecker interface {
+	CanViewPost(ctx context.Context, userID int64, postID string) (bool, error)
+}
+
+// resolveMentions returns the ids of the users mentioned in the body of the comment.
+// Mentions are best effort: unknown users, ambiguous names, users who can not view the post
+// and lookup failures are skipped and never fail the comment.
+func (s Service) resolveMentions(ctx context.Context, log *slog.Logger, comment domain.Comment) []int64 {
+	if s.accessChecker == nil {
+		return nil
+	}
+
+	var mentioned []int64
+	for _, mention := range domain.ParseMentions(comment.Body) {
+		userID, ok := s.resolveMention(ctx, log, comment.PostID, mention)
+		if !ok || userID == comment.User.ID || slices.Contains(mentioned, userID) {
+			continue
+		}
+		if !s.canViewPost(ctx, log, userID, comment.PostID) {
+			continue
+		}
+		mentioned = append(mentioned, userID)
+	}
+
+	return mentioned
+}
+
+// canViewPost reports whether the mentioned user may read the post, a failed check drops the mention
+func (s Service) canViewPost(ctx context.Context, log *slog.Logger, userID int64, postID string) bool {
+	allowed, err := s.accessChecker.CanViewPost(ctx, userID, postID)
+	if err != nil {
+		log.Warn("failed to check the post access of the mentioned user", slog.Int64("user_id", userID), logger.Err(err))
+		return false
+	}
+	return allowed
+}
+
+// resolveMention returns the id of the mentioned user, a name must match exactly one author of the post
+func (s Service) resolveMention(ctx context.Context, log *slog.Logger, postID string, mention domain.Mention) (int64, bool) {
+	if mention.ByID() {
+		user, err := s.userProvider.GetUser(ctx, mention.UserID)
+		if err != nil {
+			if !errors.Is(err, domain.ErrUserNotFound) {
+				log.Warn("failed to resolve mention", slog.Int64("user_id", mention.UserID), logger.Err(err))
+			}
+			return 0, false
+		}
+		return user.ID, true
+	}
+
+	if s.participants == nil {
+		return 0, false
+	}
+
+	users, err := s.participants.FindPostUsersByName(ctx, postID, mention.FirstName, mention.LastName)
+	if err != nil {
+		log.Warn("failed to resolve mention", slog.String("first_name", mention.FirstName), logger.Err(err))
+		return 0, false
+	}
+	if len(users) != 1 {
+		return 0, false
+	}
+
+	return users[0].ID, true
+}
+
+// mentionEvents returns the CommentMentioned event for the users of mentioned that are not in alreadyNotified,
+// the users are not notified about comments they can not read yet
+func mentionEvents(comment domain.Comment, alreadyNotified []int64) []domain.CommentEvent {
+	if comment.IsPending() || comment.IsHidden() || comment.IsDeleted() {
+		return nil
+	}
+
+	var mentioned []int64
+	for _, userID := range comment.Mentions {
+		if !slices.Contains(alreadyNotified, userID) {
+			mentioned = append(mentioned, userID)
+		}
+	}
+	if len(mentioned) == 0 {
+		return nil
+	}
+
+	return []domain.CommentEvent{domain.NewMentionEvent(comment, comment.User.ID, mentioned)}
+}
@@ -0,0 +1,167 @@
+package commentservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mentionSuite struct {
+	*Suite
+	mockParticipants *mocks.ParticipantFinder
+	mockPublisher    *mocks.EventPublisher
+	mockAccess       *mocks.PostAccessChecker
+}
+
+// newMentionSuite returns the suite with the participants, the publisher and the access checker configured
+func newMentionSuite(t *testing.T) *mentionSuite {
+	s := &mentionSuite{
+		Suite:            newSuite(t),
+		mockParticipants: mocks.NewParticipantFinder(t),
+		mockPublisher:    mocks.NewEventPublisher(t),
+		mockAccess:       mocks.NewPostAccessChecker(t),
+	}
+	s.Service = New(Config{
+		Logger:         logger.Plug(),
+		Provider:       s.mockProvider,
+		Creator:        s.mockCreator,
+		Updater:        s.mockUpdater,
+		Deleter:        s.mockDeleter,
+		Reactor:        s.mockReactor,
+		RevisionKeeper: s.mockRevisionKeeper,
+		UserProvider:   s.mockUserProvider,
+		Publisher:      s.mockPublisher,
+		Participants:   s.mockParticipants,
+		AccessChecker:  s.mockAccess,
+	})
+	return s
+}
+
+// allowView lets the users view the post p1
+func (s *mentionSuite) allowView(userIDs ...int64) {
+	for _, userID := range userIDs {
+		s.mockAccess.On("CanViewPost", mock.Anything, userID, "p1").Return(true, nil)
+	}
+}
+
+// returnCreated makes the creator return the comment it receives
+func (s *mentionSuite) returnCreated() {
+	s.mockCreator.On("CreateComment", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, comment domain.Comment) domain.Comment { return comment },
+		func(context.Context, domain.Comment) error { return nil },
+	)
+}
+
+func mentionEvent(mentioned ...int64) any {
+	return mock.MatchedBy(func(event domain.CommentEvent) bool {
+		return event.Type == domain.CommentMentioned && assert.ObjectsAreEqual(mentioned, event.Mentioned)
+	})
+}
+
+func createdEvent() any {
+	return mock.MatchedBy(func(event domain.CommentEvent) bool {
+		return event.Type == domain.CommentCreated
+	})
+}
+
+func TestService_Create_Mentions(t *testing.T) {
+	s := newMentionSuite(t)
+
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(2)).Return(domain.User{ID: 2}, nil)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(9)).Return(domain.User{}, domain.ErrUserNotFound)
+	s.mockParticipants.On("FindPostUsersByName", mock.Anything, "p1", "Anna", "Smith").Return([]domain.User{{ID: 3}}, nil)
+	s.mockParticipants.On("FindPostUsersByName", mock.Anything, "p1", "John", "Doe").Return([]domain.User{{ID: 4}, {ID: 5}}, nil)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(6)).Return(domain.User{ID: 6}, nil)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(7)).Return(domain.User{ID: 7}, nil)
+	s.allowView(2, 3)
+	s.mockAccess.On("CanViewPost", mock.Anything, int64(6), "p1").Return(false, nil)
+	s.mockAccess.On("CanViewPost", mock.Anything, int64(7), "p1").Return(false, assert.AnError)
+	s.returnCreated()
+	s.mockPublisher.On("PublishCommentEvent", mock.Anything, createdEvent()).Return()
+	s.mockPublisher.On("PublishCommentEvent", mock.Anything, mentionEvent(2, 3)).Return()
+
+	comment, err := s.Service.Create(context.Background(), CreateCommentDTO{
+		PostID: "p1",
+		UserID: 1,
+		Body:   "@id:1 @id:2 @id:9 @Anna Smith and @John Doe, @anna smith, @id:6 @id:7 @2024",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, comment.Mentions, "the author, unknown users, ambiguous names and users who can not view the post are not mentioned")
+}
+
+func TestService_Create_Mentions_LookupFails(t *testing.T) {
+	s := newMentionSuite(t)
+
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(2)).Return(domain.User{}, assert.AnError)
+	s.mockParticipants.On("FindPostUsersByName", mock.Anything, "p1", "Anna", "Smith").Return(nil, assert.AnError)
+	s.returnCreated()
+	s.mockPublisher.On("PublishCommentEvent", mock.Anything, createdEvent()).Return()
+
+	comment, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", UserID: 1, Body: "@id:2 @Anna Smith"})
+	require.NoError(t, err)
+	assert.Empty(t, comment.Mentions)
+}
+
+func TestService_Create_Mentions_Pending(t *testing.T) {
+	s := newMentionSuite(t)
+	moderator := mocks.NewContentModerator(t)
+	s.Service.moderator = moderator
+
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(2)).Return(domain.User{ID: 2}, nil)
+	moderator.On("Moderate", mock.Anything, mock.Anything).Return(func(_ context.Context, c domain.Comment) (domain.Comment, error) {
+		c.Moderation = domain.Moderation{Status: domain.ModerationPending, Reasons: []string{"too many links"}}
+		return c, nil
+	})
+	moderator.On("Record", mock.Anything, mock.Anything).Return()
+	s.allowView(2)
+	s.returnCreated()
+	s.mockPublisher.On("PublishCommentEvent", mock.Anything, createdEvent()).Return()
+
+	comment, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", UserID: 1, Body: "@id:2 http://a http://b http://c"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, comment.Mentions, "mentions are stored but not notified until the comment is readable")
+}
+
+func TestService_Update_Mentions(t *testing.T) {
+	s := newMentionSuite(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "c1").Return(domain.Comment{
+		ID: "c1", PostID: "p1", User: domain.User{ID: 1}, Body: "@id:2", Mentions: []int64{2},
+	}, nil)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(2)).Return(domain.User{ID: 2}, nil)
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(3)).Return(domain.User{ID: 3}, nil)
+	s.allowView(2, 3)
+	s.mockRevisionKeeper.On("CreateRevision", mock.Anything, mock.Anything).Return(nil)
+	s.mockUpdater.On("UpdateComment", mock.Anything, mock.MatchedBy(func(comment domain.Comment) bool {
+		return assert.ObjectsAreEqual([]int64{2, 3}, comment.Mentions)
+	})).Return(func(_ context.Context, comment domain.Comment) domain.Comment { return comment }, func(context.Context, domain.Comment) error { return nil })
+	s.mockPublisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(event domain.CommentEvent) bool {
+		return event.Type == domain.CommentUpdated
+	})).Return()
+	s.mockPublisher.On("PublishCommentEvent", mock.Anything, mentionEvent(3)).Return()
+
+	_, err := s.Service.Update(context.Background(), UpdateCommentDTO{CommentID: "c1", UserID: 1, Body: "@id:2 @id:3"})
+	assert.NoError(t, err)
+}
+
+func TestService_Create_Mentions_NoAccessChecker(t *testing.T) {
+	s := newMentionSuite(t)
+	s.Service.accessChecker = nil
+
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	s.returnCreated()
+	s.mockPublisher.On("PublishCommentEvent", mock.Anything, createdEvent()).Return()
+
+	comment, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", UserID: 1, Body: "@id:2 @Anna Smith"})
+	require.NoError(t, err)
+	assert.Empty(t, comment.Mentions, "the access of the mentioned users can not be checked")
+}
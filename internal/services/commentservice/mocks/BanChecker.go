@@ -0,0 +1,46 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BanChecker is an autogenerated mock type for the BanChecker type
+type BanChecker struct {
+	mock.Mock
+}
+
+// IsRevoked provides a mock function with given fields: ctx, userID
+func (_m *BanChecker) IsRevoked(ctx context.Context, userID int64) bool {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRevoked")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewBanChecker creates a new instance of BanChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBanChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BanChecker {
+	mock := &BanChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ContentCheck is an autogenerated mock type for the ContentCheck type
+type ContentCheck struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, comment
+func (_m *ContentCheck) Check(ctx context.Context, comment domain.Comment) (domain.ModerationResult, error) {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 domain.ModerationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Comment) (domain.ModerationResult, error)); ok {
+		return rf(ctx, comment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Comment) domain.ModerationResult); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Get(0).(domain.ModerationResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Comment) error); ok {
+		r1 = rf(ctx, comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewContentCheck creates a new instance of ContentCheck. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewContentCheck(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ContentCheck {
+	mock := &ContentCheck{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,12 +14,40 @@ type Deleter struct {
 	mock.Mock
 }
 
-// DeleteComment provides a mock function with given fields: ctx, commentID
-func (_m *Deleter) DeleteComment(ctx context.Context, commentID string) error {
+// PurgeDeletedComments provides a mock function with given fields: ctx, deletedBefore
+func (_m *Deleter) PurgeDeletedComments(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedComments")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreComment provides a mock function with given fields: ctx, commentID
+func (_m *Deleter) RestoreComment(ctx context.Context, commentID string) error {
 	ret := _m.Called(ctx, commentID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteComment")
+		panic("no return value specified for RestoreComment")
 	}
 
 	var r0 error
@@ -31,6 +60,24 @@ func (_m *Deleter) DeleteComment(ctx context.Context, commentID string) error {
 	return r0
 }
 
+// SoftDeleteComment provides a mock function with given fields: ctx, commentID, deletedBy, deletedAt
+func (_m *Deleter) SoftDeleteComment(ctx context.Context, commentID string, deletedBy int64, deletedAt time.Time) error {
+	ret := _m.Called(ctx, commentID, deletedBy, deletedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SoftDeleteComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Time) error); ok {
+		r0 = rf(ctx, commentID, deletedBy, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewDeleter creates a new instance of Deleter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDeleter(t interface {
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ModerationPolicy is an autogenerated mock type for the ModerationPolicy type
+type ModerationPolicy struct {
+	mock.Mock
+}
+
+// CanModerate provides a mock function with given fields: ctx, userID, postID
+func (_m *ModerationPolicy) CanModerate(ctx context.Context, userID int64, postID string) (bool, error) {
+	ret := _m.Called(ctx, userID, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanModerate")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (bool, error)); ok {
+		return rf(ctx, userID, postID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) bool); ok {
+		r0 = rf(ctx, userID, postID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CanModerateClub provides a mock function with given fields: ctx, userID, clubID
+func (_m *ModerationPolicy) CanModerateClub(ctx context.Context, userID int64, clubID int64) (bool, error) {
+	ret := _m.Called(ctx, userID, clubID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanModerateClub")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (bool, error)); ok {
+		return rf(ctx, userID, clubID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) bool); ok {
+		r0 = rf(ctx, userID, clubID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, userID, clubID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewModerationPolicy creates a new instance of ModerationPolicy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewModerationPolicy(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ModerationPolicy {
+	mock := &ModerationPolicy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
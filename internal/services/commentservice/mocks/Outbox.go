@@ -0,0 +1,54 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Outbox is an autogenerated mock type for the Outbox type
+type Outbox struct {
+	mock.Mock
+}
+
+// AddCommentEvents provides a mock function with given fields: ctx, events
+func (_m *Outbox) AddCommentEvents(ctx context.Context, events ...domain.CommentEvent) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCommentEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...domain.CommentEvent) error); ok {
+		r0 = rf(ctx, events...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOutbox creates a new instance of Outbox. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutbox(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Outbox {
+	mock := &Outbox{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ParticipantFinder is an autogenerated mock type for the ParticipantFinder type
+type ParticipantFinder struct {
+	mock.Mock
+}
+
+// FindPostUsersByName provides a mock function with given fields: ctx, postID, firstName, lastName
+func (_m *ParticipantFinder) FindPostUsersByName(ctx context.Context, postID string, firstName string, lastName string) ([]domain.User, error) {
+	ret := _m.Called(ctx, postID, firstName, lastName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPostUsersByName")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]domain.User, error)); ok {
+		return rf(ctx, postID, firstName, lastName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []domain.User); ok {
+		r0 = rf(ctx, postID, firstName, lastName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, postID, firstName, lastName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewParticipantFinder creates a new instance of ParticipantFinder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewParticipantFinder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ParticipantFinder {
+	mock := &ParticipantFinder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
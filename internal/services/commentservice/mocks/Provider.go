@@ -42,6 +42,80 @@ func (_m *Provider) GetComment(ctx context.Context, commentID string) (domain.Co
 	return r0, r1
 }
 
+// ListCommentReplies provides a mock function with given fields: ctx, parentID, filter
+func (_m *Provider) ListCommentReplies(ctx context.Context, parentID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error) {
+	ret := _m.Called(ctx, parentID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCommentReplies")
+	}
+
+	var r0 []domain.Comment
+	var r1 domain.PaginationMetadata
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error)); ok {
+		return rf(ctx, parentID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Filter) []domain.Comment); ok {
+		r0 = rf(ctx, parentID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.Filter) domain.PaginationMetadata); ok {
+		r1 = rf(ctx, parentID, filter)
+	} else {
+		r1 = ret.Get(1).(domain.PaginationMetadata)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, domain.Filter) error); ok {
+		r2 = rf(ctx, parentID, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListPendingComments provides a mock function with given fields: ctx, postID, filter
+func (_m *Provider) ListPendingComments(ctx context.Context, postID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error) {
+	ret := _m.Called(ctx, postID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPendingComments")
+	}
+
+	var r0 []domain.Comment
+	var r1 domain.PaginationMetadata
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error)); ok {
+		return rf(ctx, postID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Filter) []domain.Comment); ok {
+		r0 = rf(ctx, postID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.Filter) domain.PaginationMetadata); ok {
+		r1 = rf(ctx, postID, filter)
+	} else {
+		r1 = ret.Get(1).(domain.PaginationMetadata)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, domain.Filter) error); ok {
+		r2 = rf(ctx, postID, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListPostComments provides a mock function with given fields: ctx, postID, filter
 func (_m *Provider) ListPostComments(ctx context.Context, postID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error) {
 	ret := _m.Called(ctx, postID, filter)
@@ -79,6 +153,36 @@ func (_m *Provider) ListPostComments(ctx context.Context, postID string, filter
 	return r0, r1, r2
 }
 
+// ListPostCommentsChangedSince provides a mock function with given fields: ctx, postID, after, limit
+func (_m *Provider) ListPostCommentsChangedSince(ctx context.Context, postID string, after domain.SyncCursor, limit int64) ([]domain.Comment, error) {
+	ret := _m.Called(ctx, postID, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPostCommentsChangedSince")
+	}
+
+	var r0 []domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SyncCursor, int64) ([]domain.Comment, error)); ok {
+		return rf(ctx, postID, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SyncCursor, int64) []domain.Comment); ok {
+		r0 = rf(ctx, postID, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.SyncCursor, int64) error); ok {
+		r1 = rf(ctx, postID, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewProvider creates a new instance of Provider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProvider(t interface {
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Reactor is an autogenerated mock type for the Reactor type
+type Reactor struct {
+	mock.Mock
+}
+
+// AddReaction provides a mock function with given fields: ctx, reaction
+func (_m *Reactor) AddReaction(ctx context.Context, reaction domain.Reaction) (domain.Comment, error) {
+	ret := _m.Called(ctx, reaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReaction")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Reaction) (domain.Comment, error)); ok {
+		return rf(ctx, reaction)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Reaction) domain.Comment); ok {
+		r0 = rf(ctx, reaction)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Reaction) error); ok {
+		r1 = rf(ctx, reaction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveReaction provides a mock function with given fields: ctx, reaction
+func (_m *Reactor) RemoveReaction(ctx context.Context, reaction domain.Reaction) (domain.Comment, error) {
+	ret := _m.Called(ctx, reaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReaction")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Reaction) (domain.Comment, error)); ok {
+		return rf(ctx, reaction)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Reaction) domain.Comment); ok {
+		r0 = rf(ctx, reaction)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Reaction) error); ok {
+		r1 = rf(ctx, reaction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReactor creates a new instance of Reactor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Reactor {
+	mock := &Reactor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
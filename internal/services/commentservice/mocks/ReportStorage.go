@@ -0,0 +1,170 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ReportStorage is an autogenerated mock type for the ReportStorage type
+type ReportStorage struct {
+	mock.Mock
+}
+
+// CountOpenReports provides a mock function with given fields: ctx, commentID
+func (_m *ReportStorage) CountOpenReports(ctx context.Context, commentID string) (int64, error) {
+	ret := _m.Called(ctx, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOpenReports")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, commentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, commentID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, commentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReport provides a mock function with given fields: ctx, report
+func (_m *ReportStorage) CreateReport(ctx context.Context, report domain.Report) error {
+	ret := _m.Called(ctx, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Report) error); ok {
+		r0 = rf(ctx, report)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetReport provides a mock function with given fields: ctx, id
+func (_m *ReportStorage) GetReport(ctx context.Context, id string) (domain.Report, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReport")
+	}
+
+	var r0 domain.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Report, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Report); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOpenReports provides a mock function with given fields: ctx, filter
+func (_m *ReportStorage) ListOpenReports(ctx context.Context, filter domain.ReportFilter) ([]domain.Report, domain.PaginationMetadata, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOpenReports")
+	}
+
+	var r0 []domain.Report
+	var r1 domain.PaginationMetadata
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReportFilter) ([]domain.Report, domain.PaginationMetadata, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ReportFilter) []domain.Report); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ReportFilter) domain.PaginationMetadata); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(domain.PaginationMetadata)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.ReportFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ResolveReports provides a mock function with given fields: ctx, commentID, status, resolvedBy, resolvedAt
+func (_m *ReportStorage) ResolveReports(ctx context.Context, commentID string, status domain.ReportStatus, resolvedBy int64, resolvedAt time.Time) (int64, error) {
+	ret := _m.Called(ctx, commentID, status, resolvedBy, resolvedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveReports")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ReportStatus, int64, time.Time) (int64, error)); ok {
+		return rf(ctx, commentID, status, resolvedBy, resolvedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ReportStatus, int64, time.Time) int64); ok {
+		r0 = rf(ctx, commentID, status, resolvedBy, resolvedAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.ReportStatus, int64, time.Time) error); ok {
+		r1 = rf(ctx, commentID, status, resolvedBy, resolvedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReportStorage creates a new instance of ReportStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReportStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReportStorage {
+	mock := &ReportStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
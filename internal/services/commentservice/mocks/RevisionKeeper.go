@@ -0,0 +1,77 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RevisionKeeper is an autogenerated mock type for the RevisionKeeper type
+type RevisionKeeper struct {
+	mock.Mock
+}
+
+// CreateRevision provides a mock function with given fields: ctx, revision
+func (_m *RevisionKeeper) CreateRevision(ctx context.Context, revision domain.Revision) error {
+	ret := _m.Called(ctx, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRevision")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Revision) error); ok {
+		r0 = rf(ctx, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListCommentRevisions provides a mock function with given fields: ctx, commentID
+func (_m *RevisionKeeper) ListCommentRevisions(ctx context.Context, commentID string) ([]domain.Revision, error) {
+	ret := _m.Called(ctx, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCommentRevisions")
+	}
+
+	var r0 []domain.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Revision, error)); ok {
+		return rf(ctx, commentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Revision); ok {
+		r0 = rf(ctx, commentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, commentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRevisionKeeper creates a new instance of RevisionKeeper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRevisionKeeper(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RevisionKeeper {
+	mock := &RevisionKeeper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
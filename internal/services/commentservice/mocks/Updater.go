@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Updater is an autogenerated mock type for the Updater type
@@ -14,6 +16,42 @@ type Updater struct {
 	mock.Mock
 }
 
+// SetCommentHidden provides a mock function with given fields: ctx, commentID, hiddenBy, hiddenAt
+func (_m *Updater) SetCommentHidden(ctx context.Context, commentID string, hiddenBy int64, hiddenAt *time.Time) error {
+	ret := _m.Called(ctx, commentID, hiddenBy, hiddenAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCommentHidden")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, *time.Time) error); ok {
+		r0 = rf(ctx, commentID, hiddenBy, hiddenAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCommentModeration provides a mock function with given fields: ctx, commentID, moderation
+func (_m *Updater) SetCommentModeration(ctx context.Context, commentID string, moderation domain.Moderation) error {
+	ret := _m.Called(ctx, commentID, moderation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCommentModeration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Moderation) error); ok {
+		r0 = rf(ctx, commentID, moderation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateComment provides a mock function with given fields: ctx, comment
 func (_m *Updater) UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
 	ret := _m.Called(ctx, comment)
@@ -0,0 +1,249 @@
+package commentservice
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+// ModerationPolicy tells whether the user may moderate the comments of the post,
+// e.g. platform moderators or admins of the club that owns the post
+//
+//go:generate mockery --name ModerationPolicy
+type ModerationPolicy interface {
+	CanModerate(ctx context.Context, userID int64, postID string) (bool, error)
+	CanModerateClub(ctx context.Context, userID int64, clubID int64) (bool, error)
+}
+
+// Hide masks the content of the comment for everyone, only moderators of the post can hide comments
+func (s Service) Hide(ctx context.Context, dto HideCommentDTO) (domain.Comment, error) {
+	const op = "service.comment.hide"
+	log := s.log.With(slog.String("op", op))
+
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment, err := s.provider.GetComment(ctx, dto.CommentID)
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	if comment.IsDeleted() {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+
+	err = s.authorizeModerator(ctx, dto.UserID, comment.PostID)
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		hiddenAt := time.Now()
+		err := s.updater.SetCommentHidden(ctx, comment.ID, dto.UserID, &hiddenAt)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.HiddenAt = &hiddenAt
+		comment.HiddenBy = dto.UserID
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentUpdated, comment.Masked(), dto.UserID)}, nil
+	})
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	return comment.Masked(), nil
+}
+
+// Unhide shows the content of the hidden comment again
+func (s Service) Unhide(ctx context.Context, dto HideCommentDTO) (domain.Comment, error) {
+	const op = "service.comment.unhide"
+	log := s.log.With(slog.String("op", op))
+
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment, err := s.provider.GetComment(ctx, dto.CommentID)
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	if comment.IsDeleted() {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+
+	err = s.authorizeModerator(ctx, dto.UserID, comment.PostID)
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		err := s.updater.SetCommentHidden(ctx, comment.ID, dto.UserID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.HiddenAt = nil
+		comment.HiddenBy = 0
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentUpdated, comment, dto.UserID)}, nil
+	})
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	return comment, nil
+}
+
+// ListPending returns the comments of the post the moderation pipeline held for review, oldest first,
+// only moderators of the post can list them and they see the original bodies
+func (s Service) ListPending(ctx context.Context, dto ListPendingDTO) ([]domain.Comment, domain.PaginationMetadata, error) {
+	const op = "service.comment.list_pending"
+	log := s.log.With(slog.String("op", op))
+
+	err := s.authorizeModerator(ctx, dto.UserID, dto.PostID)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, handleErr(log, op, err)
+	}
+
+	filter, err := domain.NewFilter(
+		domain.WithPage(dto.Page),
+		domain.WithPageSize(dto.PageSize),
+		domain.WithSortOrder(domain.SortOrderAsc),
+	)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, handleErr(log, op, err)
+	}
+
+	comments, metadata, err := s.provider.ListPendingComments(ctx, dto.PostID, *filter)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, handleErr(log, op, err)
+	}
+
+	return comments, metadata, nil
+}
+
+// Approve publishes the comment held for review, the users it mentions are notified now
+func (s Service) Approve(ctx context.Context, dto ReviewCommentDTO) (domain.Comment, error) {
+	const op = "service.comment.approve"
+	log := s.log.With(slog.String("op", op))
+
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment, err := s.pendingComment(ctx, dto)
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		// the reasons are kept, they tell why the comment was reviewed
+		moderation := domain.Moderation{Status: domain.ModerationApproved, Reasons: comment.Moderation.Reasons}
+		err := s.updater.SetCommentModeration(ctx, comment.ID, moderation)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.Moderation = moderation
+		updated := domain.NewCommentEvent(domain.CommentUpdated, maskComment(comment), dto.UserID)
+		return append([]domain.CommentEvent{updated}, mentionEvents(comment, nil)...), nil
+	})
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	return maskComment(comment), nil
+}
+
+// Reject deletes the comment held for review on behalf of the moderator, so its author can not restore it
+func (s Service) Reject(ctx context.Context, dto ReviewCommentDTO) error {
+	const op = "service.comment.reject"
+	log := s.log.With(slog.String("op", op))
+
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return err
+	}
+
+	comment, err := s.pendingComment(ctx, dto)
+	if err != nil {
+		return handleErr(log, op, err)
+	}
+
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		deletedAt := time.Now()
+		err := s.deleter.SoftDeleteComment(ctx, comment.ID, dto.UserID, deletedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.DeletedAt = &deletedAt
+		comment.DeletedBy = dto.UserID
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentDeleted, comment.Tombstone(), dto.UserID)}, nil
+	})
+	if err != nil {
+		return handleErr(log, op, err)
+	}
+
+	return nil
+}
+
+// pendingComment returns the comment under review if the user may moderate its post
+func (s Service) pendingComment(ctx context.Context, dto ReviewCommentDTO) (domain.Comment, error) {
+	comment, err := s.provider.GetComment(ctx, dto.CommentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	if comment.IsDeleted() {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+
+	err = s.authorizeModerator(ctx, dto.UserID, comment.PostID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	if !comment.IsPending() {
+		return domain.Comment{}, domain.ErrCommentNotPending
+	}
+
+	return comment, nil
+}
+
+// authorizeModerator returns domain.ErrUnauthorized unless the policy allows the user to moderate the post
+func (s Service) authorizeModerator(ctx context.Context, userID int64, postID string) error {
+	if s.policy == nil {
+		return domain.ErrUnauthorized
+	}
+
+	allowed, err := s.policy.CanModerate(ctx, userID, postID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return domain.ErrUnauthorized
+	}
+
+	return nil
+}
+
+// authorizeClubModerator returns domain.ErrUnauthorized unless the policy allows the user to moderate the posts of the club
+func (s Service) authorizeClubModerator(ctx context.Context, userID int64, clubID int64) error {
+	if s.policy == nil {
+		return domain.ErrUnauthorized
+	}
+
+	allowed, err := s.policy.CanModerateClub(ctx, userID, clubID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return domain.ErrUnauthorized
+	}
+
+	return nil
+}
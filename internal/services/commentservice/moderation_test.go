@@ -0,0 +1,324 @@
+package commentservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newModerationSuite returns the suite with the moderation policy configured
+func newModerationSuite(t *testing.T) *Suite {
+	s := newSuite(t)
+	s.Service = New(Config{
+		Logger:           logger.Plug(),
+		Provider:         s.mockProvider,
+		Creator:          s.mockCreator,
+		Updater:          s.mockUpdater,
+		Deleter:          s.mockDeleter,
+		Reactor:          s.mockReactor,
+		RevisionKeeper:   s.mockRevisionKeeper,
+		UserProvider:     s.mockUserProvider,
+		ModerationPolicy: s.mockPolicy,
+	})
+	return s
+}
+
+func TestService_Delete_Moderator(t *testing.T) {
+	s := newModerationSuite(t)
+	defer s.mockPolicy.AssertExpectations(t)
+	defer s.mockDeleter.AssertExpectations(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", PostID: "p1", User: domain.User{ID: 1}}, nil)
+	s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(true, nil)
+	s.mockDeleter.On("SoftDeleteComment", mock.Anything, "1", int64(2), mock.Anything).Return(nil)
+
+	err := s.Service.Delete(context.Background(), DeleteCommentDTO{UserID: 2, CommentID: "1"})
+	assert.Nil(t, err)
+}
+
+func TestService_Delete_Moderator_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowed       bool
+		onCanModerate error
+		expectedError error
+	}{
+		{
+			name:          "not a moderator",
+			allowed:       false,
+			expectedError: domain.ErrUnauthorized,
+		},
+		{
+			name:          "post not found",
+			onCanModerate: domain.ErrPostNotFound,
+			expectedError: domain.ErrPostNotFound,
+		},
+		{
+			name:          "unexpected error",
+			onCanModerate: assert.AnError,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newModerationSuite(t)
+			defer s.mockPolicy.AssertExpectations(t)
+
+			s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", PostID: "p1", User: domain.User{ID: 1}}, nil)
+			s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(tc.allowed, tc.onCanModerate)
+
+			err := s.Service.Delete(context.Background(), DeleteCommentDTO{UserID: 2, CommentID: "1"})
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestService_Restore_DeletedByModerator(t *testing.T) {
+	s := newModerationSuite(t)
+	defer s.mockPolicy.AssertExpectations(t)
+
+	deletedAt := time.Now()
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", PostID: "p1", User: domain.User{ID: 1}, DeletedAt: &deletedAt, DeletedBy: 2}, nil)
+	s.mockPolicy.On("CanModerate", mock.Anything, int64(1), "p1").Return(false, nil)
+
+	_, err := s.Service.Restore(context.Background(), RestoreCommentDTO{UserID: 1, CommentID: "1"})
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestService_Hide(t *testing.T) {
+	s := newModerationSuite(t)
+	defer s.mockPolicy.AssertExpectations(t)
+	defer s.mockUpdater.AssertExpectations(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", PostID: "p1", User: domain.User{ID: 1}, Body: "spam"}, nil)
+	s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(true, nil)
+	s.mockUpdater.On("SetCommentHidden", mock.Anything, "1", int64(2), mock.AnythingOfType("*time.Time")).Return(nil)
+
+	comment, err := s.Service.Hide(context.Background(), HideCommentDTO{UserID: 2, CommentID: "1"})
+	assert.Nil(t, err)
+	assert.True(t, comment.IsHidden())
+	assert.Equal(t, int64(2), comment.HiddenBy)
+	assert.Equal(t, domain.HiddenBody, comment.Body)
+}
+
+func TestService_Hide_FailPath(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		comment       domain.Comment
+		onGetComment  error
+		allowed       bool
+		onHide        error
+		expectedError error
+	}{
+		{
+			name:          "comment not found",
+			onGetComment:  domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "comment deleted",
+			comment:       domain.Comment{ID: "1", PostID: "p1", DeletedAt: &deletedAt},
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "not a moderator",
+			comment:       domain.Comment{ID: "1", PostID: "p1"},
+			expectedError: domain.ErrUnauthorized,
+		},
+		{
+			name:          "unexpected error",
+			comment:       domain.Comment{ID: "1", PostID: "p1"},
+			allowed:       true,
+			onHide:        assert.AnError,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newModerationSuite(t)
+
+			s.mockProvider.On("GetComment", mock.Anything, "1").Return(tc.comment, tc.onGetComment)
+			if tc.onGetComment == nil && !tc.comment.IsDeleted() {
+				s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(tc.allowed, nil)
+			}
+			if tc.onHide != nil {
+				s.mockUpdater.On("SetCommentHidden", mock.Anything, "1", int64(2), mock.Anything).Return(tc.onHide)
+			}
+
+			_, err := s.Service.Hide(context.Background(), HideCommentDTO{UserID: 2, CommentID: "1"})
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestService_Unhide(t *testing.T) {
+	s := newModerationSuite(t)
+	defer s.mockUpdater.AssertExpectations(t)
+
+	hiddenAt := time.Now()
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", PostID: "p1", Body: "text", HiddenAt: &hiddenAt, HiddenBy: 2}, nil)
+	s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(true, nil)
+	s.mockUpdater.On("SetCommentHidden", mock.Anything, "1", int64(2), (*time.Time)(nil)).Return(nil)
+
+	comment, err := s.Service.Unhide(context.Background(), HideCommentDTO{UserID: 2, CommentID: "1"})
+	assert.Nil(t, err)
+	assert.False(t, comment.IsHidden())
+	assert.Equal(t, "text", comment.Body)
+}
+
+func TestService_ListByPostID_MasksHidden(t *testing.T) {
+	s := newSuite(t)
+
+	hiddenAt := time.Now()
+	s.mockProvider.On("ListPostComments", mock.Anything, "p1", mock.Anything).Return([]domain.Comment{
+		{ID: "1", Body: "visible"},
+		{ID: "2", Body: "spam", HiddenAt: &hiddenAt},
+	}, domain.PaginationMetadata{}, nil)
+
+	comments, _, err := s.Service.ListByPostID(context.Background(), "p1", domain.Filter{})
+	assert.Nil(t, err)
+	assert.Equal(t, "visible", comments[0].Body)
+	assert.Equal(t, domain.HiddenBody, comments[1].Body)
+}
+
+func pendingComment() domain.Comment {
+	return domain.Comment{
+		ID:         "1",
+		PostID:     "p1",
+		User:       domain.User{ID: 1},
+		Body:       "see https://a.kz",
+		Moderation: domain.Moderation{Status: domain.ModerationPending, Reasons: []string{"links"}},
+	}
+}
+
+func TestService_ListPending(t *testing.T) {
+	s := newModerationSuite(t)
+
+	s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(true, nil)
+	s.mockProvider.On("ListPendingComments", mock.Anything, "p1", mock.MatchedBy(func(f domain.Filter) bool {
+		return f.Page == 2 && f.PageSize == 5 && f.SortOrder == domain.SortOrderAsc
+	})).Return([]domain.Comment{pendingComment()}, domain.PaginationMetadata{TotalRecords: 6}, nil)
+
+	comments, metadata, err := s.Service.ListPending(context.Background(), ListPendingDTO{UserID: 2, PostID: "p1", Page: 2, PageSize: 5})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(6), metadata.TotalRecords)
+	if assert.Len(t, comments, 1) {
+		assert.Equal(t, "see https://a.kz", comments[0].Body, "moderators see the original body")
+	}
+}
+
+func TestService_ListPending_FailPath(t *testing.T) {
+	s := newModerationSuite(t)
+
+	s.mockPolicy.On("CanModerate", mock.Anything, int64(1), "p1").Return(false, nil)
+
+	_, _, err := s.Service.ListPending(context.Background(), ListPendingDTO{UserID: 1, PostID: "p1"})
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestService_Approve(t *testing.T) {
+	s := newModerationSuite(t)
+	publisher := mocks.NewEventPublisher(t)
+	s.Service.publisher = publisher
+
+	comment := pendingComment()
+	comment.Mentions = []int64{3}
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(comment, nil)
+	s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(true, nil)
+	s.mockUpdater.On("SetCommentModeration", mock.Anything, "1", domain.Moderation{Status: domain.ModerationApproved, Reasons: []string{"links"}}).Return(nil)
+	publisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(e domain.CommentEvent) bool {
+		return e.Type == domain.CommentUpdated && e.Comment.Body == "see https://a.kz"
+	})).Return().Once()
+	publisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(e domain.CommentEvent) bool {
+		return e.Type == domain.CommentMentioned
+	})).Return().Once()
+
+	approved, err := s.Service.Approve(context.Background(), ReviewCommentDTO{UserID: 2, CommentID: "1"})
+	assert.Nil(t, err)
+	assert.False(t, approved.IsPending())
+	assert.Equal(t, "see https://a.kz", approved.Body)
+}
+
+func TestService_Reject(t *testing.T) {
+	s := newModerationSuite(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(pendingComment(), nil)
+	s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(true, nil)
+	s.mockDeleter.On("SoftDeleteComment", mock.Anything, "1", int64(2), mock.Anything).Return(nil)
+
+	err := s.Service.Reject(context.Background(), ReviewCommentDTO{UserID: 2, CommentID: "1"})
+	assert.Nil(t, err)
+}
+
+func TestService_Approve_FailPath(t *testing.T) {
+	deletedAt := time.Now()
+	deleted := pendingComment()
+	deleted.DeletedAt = &deletedAt
+	approved := pendingComment()
+	approved.Moderation = domain.Moderation{Status: domain.ModerationApproved}
+
+	tests := []struct {
+		name          string
+		comment       domain.Comment
+		onGetComment  error
+		allowed       bool
+		onApprove     error
+		expectedError error
+	}{
+		{
+			name:          "comment not found",
+			onGetComment:  domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "comment deleted",
+			comment:       deleted,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "not a moderator",
+			comment:       pendingComment(),
+			expectedError: domain.ErrUnauthorized,
+		},
+		{
+			name:          "not pending",
+			comment:       approved,
+			allowed:       true,
+			expectedError: domain.ErrCommentNotPending,
+		},
+		{
+			name:          "unexpected error",
+			comment:       pendingComment(),
+			allowed:       true,
+			onApprove:     assert.AnError,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newModerationSuite(t)
+
+			s.mockProvider.On("GetComment", mock.Anything, "1").Return(tc.comment, tc.onGetComment)
+			if tc.onGetComment == nil && !tc.comment.IsDeleted() {
+				s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(tc.allowed, nil)
+			}
+			if tc.onApprove != nil {
+				s.mockUpdater.On("SetCommentModeration", mock.Anything, "1", mock.Anything).Return(tc.onApprove)
+			}
+
+			_, err := s.Service.Approve(context.Background(), ReviewCommentDTO{UserID: 2, CommentID: "1"})
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
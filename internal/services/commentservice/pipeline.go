@@ -0,0 +1,92 @@
+package commentservice
+
+import (
+	"context"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+// ContentModerator decides whether the body of a comment can be stored and sets the verdict on the comment,
+// Record is called with every created comment once it is stored
+//
+//go:generate mockery --name ContentModerator
+type ContentModerator interface {
+	Moderate(ctx context.Context, comment domain.Comment) (domain.Comment, error)
+	Record(ctx context.Context, comment domain.Comment)
+}
+
+// ContentCheck inspects the body of a comment, e.g. its length or banned words
+//
+//go:generate mockery --name ContentCheck
+type ContentCheck interface {
+	Check(ctx context.Context, comment domain.Comment) (domain.ModerationResult, error)
+}
+
+// ContentRecorder is a check that keeps the created comments, e.g. to detect a message sent repeatedly
+type ContentRecorder interface {
+	Record(ctx context.Context, comment domain.Comment)
+}
+
+// ModerationPipeline runs the checks in order and keeps the most severe verdict.
+// A rejection stops the pipeline, a masked body is passed on to the following checks.
+type ModerationPipeline struct {
+	checks []ContentCheck
+}
+
+func NewModerationPipeline(checks ...ContentCheck) ModerationPipeline {
+	return ModerationPipeline{checks: checks}
+}
+
+func (p ModerationPipeline) Moderate(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	moderation := domain.Moderation{Status: domain.ModerationApproved}
+
+	for _, check := range p.checks {
+		result, err := check.Check(ctx, comment)
+		if err != nil {
+			return domain.Comment{}, err
+		}
+
+		switch result.Status {
+		case domain.ModerationApproved:
+			continue
+		case domain.ModerationRejected:
+			return domain.Comment{}, domain.CommentRejectedError{Reason: result.Reason}
+		case domain.ModerationMasked:
+			comment.Body = result.Body
+		}
+
+		moderation.Reasons = append(moderation.Reasons, result.Reason)
+		if result.Status.Severer(moderation.Status) {
+			moderation.Status = result.Status
+		}
+	}
+
+	comment.Moderation = moderation
+	return comment, nil
+}
+
+// Record passes the stored comment to the checks that keep the created comments
+func (p ModerationPipeline) Record(ctx context.Context, comment domain.Comment) {
+	for _, check := range p.checks {
+		if recorder, ok := check.(ContentRecorder); ok {
+			recorder.Record(ctx, comment)
+		}
+	}
+}
+
+// moderate runs the content moderator, without one every comment is approved
+func (s Service) moderate(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	if s.moderator == nil {
+		comment.Moderation = domain.Moderation{Status: domain.ModerationApproved}
+		return comment, nil
+	}
+	return s.moderator.Moderate(ctx, comment)
+}
+
+// record passes the created comment to the content moderator
+func (s Service) record(ctx context.Context, comment domain.Comment) {
+	if s.moderator == nil {
+		return
+	}
+	s.moderator.Record(ctx, comment)
+}
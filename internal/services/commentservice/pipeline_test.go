@@ -0,0 +1,160 @@
+package commentservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModerationPipeline_Moderate(t *testing.T) {
+	masker := mocks.NewContentCheck(t)
+	reviewer := mocks.NewContentCheck(t)
+	approver := mocks.NewContentCheck(t)
+
+	masker.On("Check", mock.Anything, domain.Comment{Body: "bad words"}).
+		Return(domain.ModerationResult{Status: domain.ModerationMasked, Body: "*** words", Reason: "banned words"}, nil)
+	// the following checks get the masked body
+	reviewer.On("Check", mock.Anything, domain.Comment{Body: "*** words"}).
+		Return(domain.ModerationResult{Status: domain.ModerationPending, Body: "*** words", Reason: "links"}, nil)
+	approver.On("Check", mock.Anything, domain.Comment{Body: "*** words"}).Return(domain.Approve(), nil)
+
+	comment, err := NewModerationPipeline(masker, reviewer, approver).Moderate(context.Background(), domain.Comment{Body: "bad words"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "*** words", comment.Body)
+	assert.Equal(t, domain.Moderation{Status: domain.ModerationPending, Reasons: []string{"banned words", "links"}}, comment.Moderation)
+}
+
+func TestModerationPipeline_Moderate_Approved(t *testing.T) {
+	comment, err := NewModerationPipeline().Moderate(context.Background(), domain.Comment{Body: "hello"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello", comment.Body)
+	assert.Equal(t, domain.Moderation{Status: domain.ModerationApproved}, comment.Moderation)
+}
+
+func TestModerationPipeline_Moderate_Rejected(t *testing.T) {
+	rejecter := mocks.NewContentCheck(t)
+	next := mocks.NewContentCheck(t)
+
+	rejecter.On("Check", mock.Anything, mock.Anything).
+		Return(domain.ModerationResult{Status: domain.ModerationRejected, Reason: "body is empty"}, nil)
+
+	_, err := NewModerationPipeline(rejecter, next).Moderate(context.Background(), domain.Comment{})
+
+	assert.ErrorIs(t, err, domain.ErrCommentRejected)
+	assert.EqualError(t, err, "comment rejected by moderation: body is empty")
+	next.AssertNotCalled(t, "Check", mock.Anything, mock.Anything)
+}
+
+// recordingCheck is a content check that keeps the recorded comments
+type recordingCheck struct {
+	*mocks.ContentCheck
+	recorded []domain.Comment
+}
+
+func (c *recordingCheck) Record(_ context.Context, comment domain.Comment) {
+	c.recorded = append(c.recorded, comment)
+}
+
+func TestModerationPipeline_Record(t *testing.T) {
+	recorder := &recordingCheck{ContentCheck: mocks.NewContentCheck(t)}
+
+	NewModerationPipeline(mocks.NewContentCheck(t), recorder).Record(context.Background(), domain.Comment{Body: "hello"})
+
+	assert.Equal(t, []domain.Comment{{Body: "hello"}}, recorder.recorded)
+}
+
+func TestService_Create_Pending(t *testing.T) {
+	s, publisher := newPublisherSuite(t)
+	moderator := mocks.NewContentModerator(t)
+	s.Service.moderator = moderator
+
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	moderator.On("Moderate", mock.Anything, mock.Anything).Return(func(_ context.Context, c domain.Comment) (domain.Comment, error) {
+		c.Moderation = domain.Moderation{Status: domain.ModerationPending, Reasons: []string{"links"}}
+		return c, nil
+	})
+	s.mockCreator.On("CreateComment", mock.Anything, mock.MatchedBy(func(c domain.Comment) bool {
+		return c.IsPending() && c.Body == "see https://a.kz"
+	})).Return(func(_ context.Context, c domain.Comment) (domain.Comment, error) { return c, nil })
+	publisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(e domain.CommentEvent) bool {
+		return e.Type == domain.CommentCreated && e.Comment.Body == domain.PendingBody
+	})).Return()
+	moderator.On("Record", mock.Anything, mock.MatchedBy(func(c domain.Comment) bool {
+		return c.Body == "see https://a.kz"
+	})).Return()
+
+	comment, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", UserID: 1, Body: "see https://a.kz"})
+
+	require.NoError(t, err)
+	// the author gets the real body back
+	assert.Equal(t, "see https://a.kz", comment.Body)
+	assert.True(t, comment.IsPending())
+}
+
+func TestService_Create_Rejected(t *testing.T) {
+	s := newSuite(t)
+	moderator := mocks.NewContentModerator(t)
+	s.Service.moderator = moderator
+
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	moderator.On("Moderate", mock.Anything, mock.Anything).Return(domain.Comment{}, domain.CommentRejectedError{Reason: "body is empty"})
+
+	_, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", UserID: 1})
+
+	assert.ErrorIs(t, err, domain.ErrCommentRejected)
+	s.mockCreator.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything)
+	moderator.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestService_Create_NotStored(t *testing.T) {
+	s := newSuite(t)
+	moderator := mocks.NewContentModerator(t)
+	s.Service.moderator = moderator
+
+	s.mockUserProvider.On("GetUser", mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil)
+	moderator.On("Moderate", mock.Anything, mock.Anything).Return(func(_ context.Context, c domain.Comment) (domain.Comment, error) {
+		c.Moderation = domain.Moderation{Status: domain.ModerationApproved}
+		return c, nil
+	})
+	s.mockCreator.On("CreateComment", mock.Anything, mock.Anything).Return(domain.Comment{}, errors.New("unexpected error"))
+
+	_, err := s.Service.Create(context.Background(), CreateCommentDTO{PostID: "p1", UserID: 1, Body: "hello"})
+
+	assert.Error(t, err)
+	moderator.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestService_Update_Rejected(t *testing.T) {
+	s := newSuite(t)
+	moderator := mocks.NewContentModerator(t)
+	s.Service.moderator = moderator
+
+	s.mockProvider.On("GetComment", mock.Anything, "c1").Return(domain.Comment{ID: "c1", User: domain.User{ID: 1}, Body: "hello"}, nil)
+	moderator.On("Moderate", mock.Anything, mock.Anything).Return(domain.Comment{}, domain.CommentRejectedError{Reason: "body contains banned words"})
+
+	_, err := s.Service.Update(context.Background(), UpdateCommentDTO{CommentID: "c1", UserID: 1, Body: "spam"})
+
+	assert.ErrorIs(t, err, domain.ErrCommentRejected)
+	s.mockRevisionKeeper.AssertNotCalled(t, "CreateRevision", mock.Anything, mock.Anything)
+	s.mockUpdater.AssertNotCalled(t, "UpdateComment", mock.Anything, mock.Anything)
+}
+
+func TestService_GetByID_Pending(t *testing.T) {
+	s := newSuite(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "c1").
+		Return(domain.Comment{ID: "c1", Body: "see https://a.kz", Moderation: domain.Moderation{Status: domain.ModerationPending}}, nil)
+
+	comment, err := s.Service.GetByID(context.Background(), "c1")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.PendingBody, comment.Body)
+}
@@ -0,0 +1,100 @@
+package commentservice
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+//go:generate mockery --name Reactor
+type Reactor interface {
+	AddReaction(ctx context.Context, reaction domain.Reaction) (domain.Comment, error)
+	RemoveReaction(ctx context.Context, reaction domain.Reaction) (domain.Comment, error)
+}
+
+// AddReaction adds the user's emoji reaction to the comment and returns the comment with updated counters as it is shown to users
+func (s Service) AddReaction(ctx context.Context, dto ReactionDTO) (domain.Comment, error) {
+	const op = "service.comment.add_reaction"
+	log := s.log.With(slog.String("op", op))
+
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return domain.Comment{}, err
+	}
+
+	err := domain.ValidateReaction(dto.Emoji)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment, err := s.provider.GetComment(ctx, dto.CommentID)
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+	if comment.IsDeleted() {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		var err error
+		comment, err = s.reactor.AddReaction(ctx, domain.Reaction{
+			CommentID: dto.CommentID,
+			UserID:    dto.UserID,
+			Emoji:     dto.Emoji,
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentReactionsUpdated, maskComment(comment), dto.UserID)}, nil
+	})
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	return maskComment(comment), nil
+}
+
+// RemoveReaction removes the user's emoji reaction from the comment and returns the comment with updated counters as it is shown to users
+func (s Service) RemoveReaction(ctx context.Context, dto ReactionDTO) (domain.Comment, error) {
+	const op = "service.comment.remove_reaction"
+	log := s.log.With(slog.String("op", op))
+
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return domain.Comment{}, err
+	}
+
+	err := domain.ValidateReaction(dto.Emoji)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment, err := s.provider.GetComment(ctx, dto.CommentID)
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+	if comment.IsDeleted() {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		var err error
+		comment, err = s.reactor.RemoveReaction(ctx, domain.Reaction{
+			CommentID: dto.CommentID,
+			UserID:    dto.UserID,
+			Emoji:     dto.Emoji,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentReactionsUpdated, maskComment(comment), dto.UserID)}, nil
+	})
+	if err != nil {
+		return domain.Comment{}, handleErr(log, op, err)
+	}
+
+	return maskComment(comment), nil
+}
@@ -0,0 +1,169 @@
+package commentservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_AddReaction(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+	defer s.mockReactor.AssertExpectations(t)
+
+	reacted := domain.Comment{ID: "1", Reactions: map[string]int32{"👍": 1}}
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1"}, nil)
+	s.mockReactor.On("AddReaction", mock.Anything, mock.MatchedBy(func(r domain.Reaction) bool {
+		return r.CommentID == "1" && r.UserID == 1 && r.Emoji == "👍"
+	})).Return(reacted, nil)
+
+	comment, err := s.Service.AddReaction(context.Background(), ReactionDTO{UserID: 1, CommentID: "1", Emoji: "👍"})
+	assert.Nil(t, err)
+	assert.Equal(t, reacted, comment)
+}
+
+func TestService_AddReaction_FailPath(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		dto           ReactionDTO
+		comment       domain.Comment
+		onGetComment  error
+		onAddReaction error
+		expectedError error
+	}{
+		{
+			name:          "invalid reaction",
+			dto:           ReactionDTO{UserID: 1, CommentID: "1", Emoji: "+1"},
+			expectedError: domain.ErrInvalidReaction,
+		},
+		{
+			name:          "comment not found",
+			dto:           ReactionDTO{UserID: 1, CommentID: "1", Emoji: "👍"},
+			onGetComment:  domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "deleted comment",
+			dto:           ReactionDTO{UserID: 1, CommentID: "1", Emoji: "👍"},
+			comment:       domain.Comment{ID: "1", DeletedAt: &deletedAt},
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "unexpected error",
+			dto:           ReactionDTO{UserID: 1, CommentID: "1", Emoji: "👍"},
+			comment:       domain.Comment{ID: "1"},
+			onAddReaction: assert.AnError,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuite(t)
+			defer s.mockProvider.AssertExpectations(t)
+			defer s.mockReactor.AssertExpectations(t)
+
+			if tc.expectedError != domain.ErrInvalidReaction {
+				s.mockProvider.On("GetComment", mock.Anything, "1").Return(tc.comment, tc.onGetComment)
+			}
+			if tc.onAddReaction != nil {
+				s.mockReactor.On("AddReaction", mock.Anything, mock.Anything).Return(domain.Comment{}, tc.onAddReaction)
+			}
+
+			_, err := s.Service.AddReaction(context.Background(), tc.dto)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestService_AddReaction_HiddenComment(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+	defer s.mockReactor.AssertExpectations(t)
+
+	hiddenAt := time.Now()
+	hidden := domain.Comment{ID: "1", Body: "hidden body", HiddenAt: &hiddenAt}
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(hidden, nil)
+	s.mockReactor.On("AddReaction", mock.Anything, mock.Anything).Return(hidden, nil)
+
+	comment, err := s.Service.AddReaction(context.Background(), ReactionDTO{UserID: 1, CommentID: "1", Emoji: "👍"})
+	assert.Nil(t, err)
+	assert.Equal(t, domain.HiddenBody, comment.Body)
+}
+
+func TestService_RemoveReaction(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+	defer s.mockReactor.AssertExpectations(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1"}, nil)
+	s.mockReactor.On("RemoveReaction", mock.Anything, domain.Reaction{CommentID: "1", UserID: 1, Emoji: "👍"}).Return(domain.Comment{ID: "1"}, nil)
+
+	comment, err := s.Service.RemoveReaction(context.Background(), ReactionDTO{UserID: 1, CommentID: "1", Emoji: "👍"})
+	assert.Nil(t, err)
+	assert.Empty(t, comment.Reactions)
+}
+
+func TestService_RemoveReaction_FailPath(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name             string
+		dto              ReactionDTO
+		comment          domain.Comment
+		onGetComment     error
+		onRemoveReaction error
+		expectedError    error
+	}{
+		{
+			name:          "invalid reaction",
+			dto:           ReactionDTO{UserID: 1, CommentID: "1", Emoji: ""},
+			expectedError: domain.ErrInvalidReaction,
+		},
+		{
+			name:          "comment not found",
+			dto:           ReactionDTO{UserID: 1, CommentID: "1", Emoji: "👍"},
+			onGetComment:  domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "deleted comment",
+			dto:           ReactionDTO{UserID: 1, CommentID: "1", Emoji: "👍"},
+			comment:       domain.Comment{ID: "1", DeletedAt: &deletedAt},
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:             "unexpected error",
+			dto:              ReactionDTO{UserID: 1, CommentID: "1", Emoji: "👍"},
+			comment:          domain.Comment{ID: "1"},
+			onRemoveReaction: assert.AnError,
+			expectedError:    domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuite(t)
+			defer s.mockProvider.AssertExpectations(t)
+			defer s.mockReactor.AssertExpectations(t)
+
+			if tc.expectedError != domain.ErrInvalidReaction {
+				s.mockProvider.On("GetComment", mock.Anything, "1").Return(tc.comment, tc.onGetComment)
+			}
+			if tc.onRemoveReaction != nil {
+				s.mockReactor.On("RemoveReaction", mock.Anything, mock.Anything).Return(domain.Comment{}, tc.onRemoveReaction)
+			}
+
+			_, err := s.Service.RemoveReaction(context.Background(), tc.dto)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package commentservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+)
+
+// maxReportReasonLength is the longest reason of a report in characters
+const maxReportReasonLength = 500
+
+//go:generate mockery --name ReportStorage
+type ReportStorage interface {
+	CreateReport(ctx context.Context, report domain.Report) error
+	GetReport(ctx context.Context, id string) (domain.Report, error)
+	CountOpenReports(ctx context.Context, commentID string) (int64, error)
+	ListOpenReports(ctx context.Context, filter domain.ReportFilter) ([]domain.Report, domain.PaginationMetadata, error)
+	ResolveReports(ctx context.Context, commentID string, status domain.ReportStatus, resolvedBy int64, resolvedAt time.Time) (int64, error)
+}
+
+// ClubResolver returns the club that owns the post, reports are queued for the admins of that club
+//
+//go:generate mockery --name ClubResolver
+type ClubResolver interface {
+	GetPostClubID(ctx context.Context, postID string) (int64, error)
+}
+
+// Report records the complaint of the user about the comment,
+// the comment is hidden once enough distinct users reported it
+func (s Service) Report(ctx context.Context, dto ReportCommentDTO) (domain.Report, error) {
+	const op = "service.comment.report"
+	log := s.log.With(slog.String("op", op))
+
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return domain.Report{}, err
+	}
+
+	reason := strings.TrimSpace(dto.Reason)
+	if utf8.RuneCountInString(reason) > maxReportReasonLength {
+		return domain.Report{}, fmt.Errorf("%w: reason is longer than %d characters", domain.ErrInvalidArg, maxReportReasonLength)
+	}
+
+	comment, err := s.provider.GetComment(ctx, dto.CommentID)
+	if err != nil {
+		return domain.Report{}, handleErr(log, op, err)
+	}
+
+	if comment.IsDeleted() {
+		return domain.Report{}, domain.ErrCommentNotFound
+	}
+
+	if comment.User.ID == dto.UserID {
+		return domain.Report{}, domain.ErrOwnCommentReport
+	}
+
+	clubID, err := s.clubResolver.GetPostClubID(ctx, comment.PostID)
+	if err != nil {
+		return domain.Report{}, handleErr(log, op, err)
+	}
+
+	report := domain.Report{
+		ID:         domain.NewID(),
+		CommentID:  comment.ID,
+		PostID:     comment.PostID,
+		ClubID:     clubID,
+		ReporterID: dto.UserID,
+		Reason:     reason,
+		Status:     domain.ReportOpen,
+		CreatedAt:  time.Now(),
+	}
+
+	err = s.reports.CreateReport(ctx, report)
+	if err != nil {
+		return domain.Report{}, handleErr(log, op, err)
+	}
+
+	// the report is stored, failing to hide the comment must not fail the reporter
+	err = s.hideReported(ctx, comment)
+	if err != nil {
+		log.Error("failed to hide reported comment", slog.String("comment_id", comment.ID), logger.Err(err))
+	}
+
+	return report, nil
+}
+
+// hideReported hides the comment once the number of its reporters reaches the configured threshold,
+// the comment is hidden on behalf of nobody, so dismissing the reports shows it again
+func (s Service) hideReported(ctx context.Context, comment domain.Comment) error {
+	if s.reportsToHide <= 0 || comment.IsHidden() {
+		return nil
+	}
+
+	reporters, err := s.reports.CountOpenReports(ctx, comment.ID)
+	if err != nil {
+		return err
+	}
+	if reporters < int64(s.reportsToHide) {
+		return nil
+	}
+
+	return s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		hiddenAt := time.Now()
+		err := s.updater.SetCommentHidden(ctx, comment.ID, 0, &hiddenAt)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.HiddenAt = &hiddenAt
+		comment.HiddenBy = 0
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentUpdated, maskComment(comment), 0)}, nil
+	})
+}
+
+// ListReports returns the open reports of the club or of the post, oldest first, only their moderators can list them
+func (s Service) ListReports(ctx context.Context, dto ListReportsDTO) ([]domain.Report, domain.PaginationMetadata, error) {
+	const op = "service.comment.list_reports"
+	log := s.log.With(slog.String("op", op))
+
+	var err error
+	switch {
+	case dto.PostID != "" && dto.ClubID != 0, dto.PostID == "" && dto.ClubID == 0:
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("%w: either post id or club id is required", domain.ErrInvalidArg)
+	case dto.PostID != "":
+		err = s.authorizeModerator(ctx, dto.UserID, dto.PostID)
+	default:
+		err = s.authorizeClubModerator(ctx, dto.UserID, dto.ClubID)
+	}
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, handleErr(log, op, err)
+	}
+
+	filter := domain.ReportFilter{
+		ClubID:   dto.ClubID,
+		PostID:   dto.PostID,
+		Page:     max(dto.Page, 1),
+		PageSize: dto.PageSize,
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 10
+	}
+
+	reports, metadata, err := s.reports.ListOpenReports(ctx, filter)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, handleErr(log, op, err)
+	}
+
+	return reports, metadata, nil
+}
+
+// ResolveReport applies the decision of the moderator to the reported comment and closes all its open reports
+func (s Service) ResolveReport(ctx context.Context, dto ResolveReportDTO) (domain.Report, error) {
+	const op = "service.comment.resolve_report"
+	log := s.log.With(slog.String("op", op))
+
+	if err := s.checkBan(ctx, log, dto.UserID); err != nil {
+		return domain.Report{}, err
+	}
+
+	_, err := domain.ParseReportAction(string(dto.Action))
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	report, err := s.reports.GetReport(ctx, dto.ReportID)
+	if err != nil {
+		return domain.Report{}, handleErr(log, op, err)
+	}
+
+	if !report.IsOpen() {
+		return domain.Report{}, domain.ErrReportResolved
+	}
+
+	err = s.authorizeModerator(ctx, dto.UserID, report.PostID)
+	if err != nil {
+		return domain.Report{}, handleErr(log, op, err)
+	}
+
+	comment, err := s.provider.GetComment(ctx, report.CommentID)
+	// a purged comment has only the reports left to close
+	purged := errors.Is(err, domain.ErrCommentNotFound)
+	if err != nil && !purged {
+		return domain.Report{}, handleErr(log, op, err)
+	}
+
+	resolvedAt := time.Now()
+	err = s.store(ctx, func(ctx context.Context) ([]domain.CommentEvent, error) {
+		var events []domain.CommentEvent
+		if !purged && !comment.IsDeleted() {
+			var err error
+			events, err = s.applyReportAction(ctx, comment, dto.Action, dto.UserID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		_, err := s.reports.ResolveReports(ctx, report.CommentID, dto.Action.Status(), dto.UserID, resolvedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		return events, nil
+	})
+	if err != nil {
+		return domain.Report{}, handleErr(log, op, err)
+	}
+
+	report.Status = dto.Action.Status()
+	report.ResolvedAt = &resolvedAt
+	report.ResolvedBy = dto.UserID
+
+	return report, nil
+}
+
+// applyReportAction hides or deletes the comment, dismissing the reports shows the comment again if they hid it.
+// It returns the events of the change.
+func (s Service) applyReportAction(ctx context.Context, comment domain.Comment, action domain.ReportAction, moderatorID int64) ([]domain.CommentEvent, error) {
+	switch action {
+	case domain.ReportActionHide:
+		hiddenAt := time.Now()
+		err := s.updater.SetCommentHidden(ctx, comment.ID, moderatorID, &hiddenAt)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.HiddenAt = &hiddenAt
+		comment.HiddenBy = moderatorID
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentUpdated, maskComment(comment), moderatorID)}, nil
+	case domain.ReportActionDelete:
+		deletedAt := time.Now()
+		err := s.deleter.SoftDeleteComment(ctx, comment.ID, moderatorID, deletedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.DeletedAt = &deletedAt
+		comment.DeletedBy = moderatorID
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentDeleted, comment.Tombstone(), moderatorID)}, nil
+	case domain.ReportActionDismiss:
+		if !comment.IsHidden() || comment.HiddenBy != 0 {
+			return nil, nil
+		}
+
+		err := s.updater.SetCommentHidden(ctx, comment.ID, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.HiddenAt = nil
+		return []domain.CommentEvent{domain.NewCommentEvent(domain.CommentUpdated, maskComment(comment), moderatorID)}, nil
+	default:
+		return nil, domain.ErrInvalidReportAction
+	}
+}
@@ -0,0 +1,400 @@
+package commentservice
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type reportSuite struct {
+	*Suite
+	mockReports      *mocks.ReportStorage
+	mockClubResolver *mocks.ClubResolver
+	mockPublisher    *mocks.EventPublisher
+}
+
+// newReportSuite returns the suite with the reports configured, comments are hidden after two reporters
+func newReportSuite(t *testing.T) *reportSuite {
+	s := &reportSuite{
+		Suite:            newSuite(t),
+		mockReports:      mocks.NewReportStorage(t),
+		mockClubResolver: mocks.NewClubResolver(t),
+		mockPublisher:    mocks.NewEventPublisher(t),
+	}
+	s.Service = New(Config{
+		Logger:           logger.Plug(),
+		Provider:         s.mockProvider,
+		Creator:          s.mockCreator,
+		Updater:          s.mockUpdater,
+		Deleter:          s.mockDeleter,
+		Reactor:          s.mockReactor,
+		RevisionKeeper:   s.mockRevisionKeeper,
+		UserProvider:     s.mockUserProvider,
+		ModerationPolicy: s.mockPolicy,
+		Publisher:        s.mockPublisher,
+		Reports:          s.mockReports,
+		ClubResolver:     s.mockClubResolver,
+		ReportsToHide:    2,
+	})
+	return s
+}
+
+func reportedComment() domain.Comment {
+	return domain.Comment{ID: "c1", PostID: "p1", User: domain.User{ID: 1}, Body: "body"}
+}
+
+func TestService_Report(t *testing.T) {
+	s := newReportSuite(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "c1").Return(reportedComment(), nil)
+	s.mockClubResolver.On("GetPostClubID", mock.Anything, "p1").Return(int64(10), nil)
+	s.mockReports.On("CreateReport", mock.Anything, mock.MatchedBy(func(report domain.Report) bool {
+		return report.CommentID == "c1" && report.ClubID == 10 && report.ReporterID == 2 &&
+			report.Reason == "spam" && report.Status == domain.ReportOpen
+	})).Return(nil)
+	s.mockReports.On("CountOpenReports", mock.Anything, "c1").Return(int64(1), nil)
+
+	report, err := s.Service.Report(context.Background(), ReportCommentDTO{UserID: 2, CommentID: "c1", Reason: " spam "})
+	require.NoError(t, err)
+	assert.Equal(t, "spam", report.Reason)
+	assert.Equal(t, int64(10), report.ClubID)
+}
+
+func TestService_Report_HidesAfterThreshold(t *testing.T) {
+	s := newReportSuite(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "c1").Return(reportedComment(), nil)
+	s.mockClubResolver.On("GetPostClubID", mock.Anything, "p1").Return(int64(10), nil)
+	s.mockReports.On("CreateReport", mock.Anything, mock.Anything).Return(nil)
+	s.mockReports.On("CountOpenReports", mock.Anything, "c1").Return(int64(2), nil)
+	s.mockUpdater.On("SetCommentHidden", mock.Anything, "c1", int64(0), mock.AnythingOfType("*time.Time")).Return(nil)
+	s.mockPublisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(event domain.CommentEvent) bool {
+		return event.Type == domain.CommentUpdated && event.Comment.Body == domain.HiddenBody && event.Comment.IsHidden()
+	})).Return()
+
+	_, err := s.Service.Report(context.Background(), ReportCommentDTO{UserID: 2, CommentID: "c1"})
+	assert.NoError(t, err)
+}
+
+func TestService_Report_HideFails(t *testing.T) {
+	s := newReportSuite(t)
+
+	s.mockProvider.On("GetComment", mock.Anything, "c1").Return(reportedComment(), nil)
+	s.mockClubResolver.On("GetPostClubID", mock.Anything, "p1").Return(int64(10), nil)
+	s.mockReports.On("CreateReport", mock.Anything, mock.Anything).Return(nil)
+	s.mockReports.On("CountOpenReports", mock.Anything, "c1").Return(int64(0), assert.AnError)
+
+	_, err := s.Service.Report(context.Background(), ReportCommentDTO{UserID: 2, CommentID: "c1"})
+	assert.NoError(t, err, "the stored report must not fail because the comment could not be hidden")
+}
+
+func TestService_Report_FailPath(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name            string
+		dto             ReportCommentDTO
+		comment         domain.Comment
+		onGetComment    error
+		onGetPostClubID error
+		onCreateReport  error
+		expectedError   error
+	}{
+		{
+			name:          "reason too long",
+			dto:           ReportCommentDTO{UserID: 2, CommentID: "c1", Reason: strings.Repeat("a", maxReportReasonLength+1)},
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "comment not found",
+			dto:           ReportCommentDTO{UserID: 2, CommentID: "c1"},
+			onGetComment:  domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "deleted comment",
+			dto:           ReportCommentDTO{UserID: 2, CommentID: "c1"},
+			comment:       domain.Comment{ID: "c1", PostID: "p1", User: domain.User{ID: 1}, DeletedAt: &deletedAt},
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "own comment",
+			dto:           ReportCommentDTO{UserID: 1, CommentID: "c1"},
+			comment:       reportedComment(),
+			expectedError: domain.ErrOwnCommentReport,
+		},
+		{
+			name:            "post not found",
+			dto:             ReportCommentDTO{UserID: 2, CommentID: "c1"},
+			comment:         reportedComment(),
+			onGetPostClubID: domain.ErrPostNotFound,
+			expectedError:   domain.ErrPostNotFound,
+		},
+		{
+			name:           "already reported",
+			dto:            ReportCommentDTO{UserID: 2, CommentID: "c1"},
+			comment:        reportedComment(),
+			onCreateReport: domain.ErrAlreadyReported,
+			expectedError:  domain.ErrAlreadyReported,
+		},
+		{
+			name:           "unexpected error",
+			dto:            ReportCommentDTO{UserID: 2, CommentID: "c1"},
+			comment:        reportedComment(),
+			onCreateReport: assert.AnError,
+			expectedError:  domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newReportSuite(t)
+
+			if tt.comment.ID != "" || tt.onGetComment != nil {
+				s.mockProvider.On("GetComment", mock.Anything, "c1").Return(tt.comment, tt.onGetComment)
+			}
+			if tt.comment.ID != "" && !tt.comment.IsDeleted() && tt.comment.User.ID != tt.dto.UserID {
+				s.mockClubResolver.On("GetPostClubID", mock.Anything, "p1").Return(int64(10), tt.onGetPostClubID)
+				if tt.onGetPostClubID == nil {
+					s.mockReports.On("CreateReport", mock.Anything, mock.Anything).Return(tt.onCreateReport)
+				}
+			}
+
+			_, err := s.Service.Report(context.Background(), tt.dto)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestService_ListReports(t *testing.T) {
+	reports := []domain.Report{{ID: "r1", CommentID: "c1", ClubID: 10, Status: domain.ReportOpen}}
+
+	t.Run("club", func(t *testing.T) {
+		s := newReportSuite(t)
+		s.mockPolicy.On("CanModerateClub", mock.Anything, int64(2), int64(10)).Return(true, nil)
+		s.mockReports.On("ListOpenReports", mock.Anything, domain.ReportFilter{ClubID: 10, Page: 1, PageSize: 10}).
+			Return(reports, domain.PaginationMetadata{TotalRecords: 1}, nil)
+
+		got, metadata, err := s.Service.ListReports(context.Background(), ListReportsDTO{UserID: 2, ClubID: 10})
+		require.NoError(t, err)
+		assert.Equal(t, reports, got)
+		assert.Equal(t, int32(1), metadata.TotalRecords)
+	})
+
+	t.Run("post", func(t *testing.T) {
+		s := newReportSuite(t)
+		s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(true, nil)
+		s.mockReports.On("ListOpenReports", mock.Anything, domain.ReportFilter{PostID: "p1", Page: 2, PageSize: 5}).
+			Return(reports, domain.PaginationMetadata{TotalRecords: 1}, nil)
+
+		got, _, err := s.Service.ListReports(context.Background(), ListReportsDTO{UserID: 2, PostID: "p1", Page: 2, PageSize: 5})
+		require.NoError(t, err)
+		assert.Equal(t, reports, got)
+	})
+}
+
+func TestService_ListReports_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		dto           ListReportsDTO
+		allowed       bool
+		onCanModerate error
+		onList        error
+		expectedError error
+	}{
+		{
+			name:          "neither club nor post",
+			dto:           ListReportsDTO{UserID: 2},
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "both club and post",
+			dto:           ListReportsDTO{UserID: 2, ClubID: 10, PostID: "p1"},
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "not a club admin",
+			dto:           ListReportsDTO{UserID: 2, ClubID: 10},
+			expectedError: domain.ErrUnauthorized,
+		},
+		{
+			name:          "club not found",
+			dto:           ListReportsDTO{UserID: 2, ClubID: 10},
+			onCanModerate: domain.ErrClubNotFound,
+			expectedError: domain.ErrClubNotFound,
+		},
+		{
+			name:          "unexpected error",
+			dto:           ListReportsDTO{UserID: 2, ClubID: 10},
+			allowed:       true,
+			onList:        assert.AnError,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newReportSuite(t)
+
+			if tt.dto.ClubID != 0 && tt.dto.PostID == "" {
+				s.mockPolicy.On("CanModerateClub", mock.Anything, int64(2), int64(10)).Return(tt.allowed, tt.onCanModerate)
+			}
+			if tt.allowed {
+				s.mockReports.On("ListOpenReports", mock.Anything, mock.Anything).Return(nil, domain.PaginationMetadata{}, tt.onList)
+			}
+
+			_, _, err := s.Service.ListReports(context.Background(), tt.dto)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestService_ResolveReport(t *testing.T) {
+	hiddenAt := time.Now()
+	autoHidden := reportedComment()
+	autoHidden.HiddenAt = &hiddenAt
+
+	tests := []struct {
+		name    string
+		action  domain.ReportAction
+		comment domain.Comment
+		expect  func(s *reportSuite)
+	}{
+		{
+			name:    "hide",
+			action:  domain.ReportActionHide,
+			comment: reportedComment(),
+			expect: func(s *reportSuite) {
+				s.mockUpdater.On("SetCommentHidden", mock.Anything, "c1", int64(2), mock.AnythingOfType("*time.Time")).Return(nil)
+				s.mockPublisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(event domain.CommentEvent) bool {
+					return event.Type == domain.CommentUpdated && event.Comment.HiddenBy == 2
+				})).Return()
+			},
+		},
+		{
+			name:    "delete",
+			action:  domain.ReportActionDelete,
+			comment: reportedComment(),
+			expect: func(s *reportSuite) {
+				s.mockDeleter.On("SoftDeleteComment", mock.Anything, "c1", int64(2), mock.Anything).Return(nil)
+				s.mockPublisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(event domain.CommentEvent) bool {
+					return event.Type == domain.CommentDeleted && event.Comment.Body == domain.TombstoneBody
+				})).Return()
+			},
+		},
+		{
+			name:    "dismiss shows the automatically hidden comment",
+			action:  domain.ReportActionDismiss,
+			comment: autoHidden,
+			expect: func(s *reportSuite) {
+				s.mockUpdater.On("SetCommentHidden", mock.Anything, "c1", int64(0), (*time.Time)(nil)).Return(nil)
+				s.mockPublisher.On("PublishCommentEvent", mock.Anything, mock.MatchedBy(func(event domain.CommentEvent) bool {
+					return event.Type == domain.CommentUpdated && event.Comment.Body == "body"
+				})).Return()
+			},
+		},
+		{
+			name:    "dismiss keeps the comment",
+			action:  domain.ReportActionDismiss,
+			comment: reportedComment(),
+			expect:  func(s *reportSuite) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newReportSuite(t)
+
+			s.mockReports.On("GetReport", mock.Anything, "r1").Return(domain.Report{ID: "r1", CommentID: "c1", PostID: "p1", Status: domain.ReportOpen}, nil)
+			s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(true, nil)
+			s.mockProvider.On("GetComment", mock.Anything, "c1").Return(tt.comment, nil)
+			tt.expect(s)
+			s.mockReports.On("ResolveReports", mock.Anything, "c1", tt.action.Status(), int64(2), mock.Anything).Return(int64(2), nil)
+
+			report, err := s.Service.ResolveReport(context.Background(), ResolveReportDTO{UserID: 2, ReportID: "r1", Action: tt.action})
+			require.NoError(t, err)
+			assert.Equal(t, tt.action.Status(), report.Status)
+			assert.Equal(t, int64(2), report.ResolvedBy)
+			assert.NotNil(t, report.ResolvedAt)
+		})
+	}
+}
+
+func TestService_ResolveReport_PurgedComment(t *testing.T) {
+	s := newReportSuite(t)
+
+	s.mockReports.On("GetReport", mock.Anything, "r1").Return(domain.Report{ID: "r1", CommentID: "c1", PostID: "p1", Status: domain.ReportOpen}, nil)
+	s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(true, nil)
+	s.mockProvider.On("GetComment", mock.Anything, "c1").Return(domain.Comment{}, domain.ErrCommentNotFound)
+	s.mockReports.On("ResolveReports", mock.Anything, "c1", domain.ReportDeleted, int64(2), mock.Anything).Return(int64(1), nil)
+
+	_, err := s.Service.ResolveReport(context.Background(), ResolveReportDTO{UserID: 2, ReportID: "r1", Action: domain.ReportActionDelete})
+	assert.NoError(t, err)
+}
+
+func TestService_ResolveReport_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		action        domain.ReportAction
+		report        domain.Report
+		onGetReport   error
+		allowed       bool
+		onCanModerate error
+		expectedError error
+	}{
+		{
+			name:          "invalid action",
+			action:        "ban",
+			expectedError: domain.ErrInvalidReportAction,
+		},
+		{
+			name:          "report not found",
+			action:        domain.ReportActionHide,
+			onGetReport:   domain.ErrReportNotFound,
+			expectedError: domain.ErrReportNotFound,
+		},
+		{
+			name:          "already resolved",
+			action:        domain.ReportActionHide,
+			report:        domain.Report{ID: "r1", CommentID: "c1", PostID: "p1", Status: domain.ReportDismissed},
+			expectedError: domain.ErrReportResolved,
+		},
+		{
+			name:          "not a moderator",
+			action:        domain.ReportActionHide,
+			report:        domain.Report{ID: "r1", CommentID: "c1", PostID: "p1", Status: domain.ReportOpen},
+			expectedError: domain.ErrUnauthorized,
+		},
+		{
+			name:          "unexpected error",
+			action:        domain.ReportActionHide,
+			report:        domain.Report{ID: "r1", CommentID: "c1", PostID: "p1", Status: domain.ReportOpen},
+			onCanModerate: assert.AnError,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newReportSuite(t)
+
+			if tt.report.ID != "" || tt.onGetReport != nil {
+				s.mockReports.On("GetReport", mock.Anything, "r1").Return(tt.report, tt.onGetReport)
+			}
+			if tt.report.IsOpen() {
+				s.mockPolicy.On("CanModerate", mock.Anything, int64(2), "p1").Return(tt.allowed, tt.onCanModerate)
+			}
+
+			_, err := s.Service.ResolveReport(context.Background(), ResolveReportDTO{UserID: 2, ReportID: "r1", Action: tt.action})
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package commentservice
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+//go:generate mockery --name RevisionKeeper
+type RevisionKeeper interface {
+	CreateRevision(ctx context.Context, revision domain.Revision) error
+	ListCommentRevisions(ctx context.Context, commentID string) ([]domain.Revision, error)
+}
+
+// ListRevisions returns the previous versions of the comment, oldest first
+func (s Service) ListRevisions(ctx context.Context, dto ListRevisionsDTO) ([]domain.Revision, error) {
+	const op = "service.comment.list_revisions"
+	log := s.log.With(slog.String("op", op))
+
+	comment, err := s.provider.GetComment(ctx, dto.CommentID)
+	if err != nil {
+		return nil, handleErr(log, op, err)
+	}
+
+	if comment.User.ID != dto.UserID {
+		err = s.authorizeModerator(ctx, dto.UserID, comment.PostID)
+		if err != nil {
+			return nil, handleErr(log, op, err)
+		}
+	}
+
+	revisions, err := s.revisionKeeper.ListCommentRevisions(ctx, dto.CommentID)
+	if err != nil {
+		return nil, handleErr(log, op, err)
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,73 @@
+package commentservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_ListRevisions(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+	defer s.mockRevisionKeeper.AssertExpectations(t)
+
+	expectedRevisions := []domain.Revision{
+		{ID: "r1", CommentID: "1", Body: "first", EditorID: 1, EditedAt: time.Now().Add(-time.Hour)},
+		{ID: "r2", CommentID: "1", Body: "second", EditorID: 1, EditedAt: time.Now()},
+	}
+
+	s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", User: domain.User{ID: 1}}, nil)
+	s.mockRevisionKeeper.On("ListCommentRevisions", mock.Anything, "1").Return(expectedRevisions, nil)
+
+	revisions, err := s.Service.ListRevisions(context.Background(), ListRevisionsDTO{UserID: 1, CommentID: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, expectedRevisions, revisions)
+}
+
+func TestService_ListRevisions_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		dto           ListRevisionsDTO
+		onGetComment  error
+		onList        error
+		expectedError error
+	}{
+		{
+			name:          "comment not found",
+			dto:           ListRevisionsDTO{UserID: 1, CommentID: "1"},
+			onGetComment:  domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "unauthorized",
+			dto:           ListRevisionsDTO{UserID: 2, CommentID: "1"},
+			expectedError: domain.ErrUnauthorized,
+		},
+		{
+			name:          "unexpected error",
+			dto:           ListRevisionsDTO{UserID: 1, CommentID: "1"},
+			onList:        assert.AnError,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuite(t)
+			defer s.mockProvider.AssertExpectations(t)
+			defer s.mockRevisionKeeper.AssertExpectations(t)
+
+			s.mockProvider.On("GetComment", mock.Anything, "1").Return(domain.Comment{ID: "1", User: domain.User{ID: 1}}, tc.onGetComment)
+			if tc.onList != nil {
+				s.mockRevisionKeeper.On("ListCommentRevisions", mock.Anything, "1").Return(nil, tc.onList)
+			}
+
+			_, err := s.Service.ListRevisions(context.Background(), tc.dto)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
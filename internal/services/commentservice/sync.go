@@ -0,0 +1,47 @@
+package commentservice
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+// SyncLimit is the maximum number of changed comments returned by Sync,
+// clients that missed more should reload the post instead
+const SyncLimit = 500
+
+// SyncResult is the page of the changed comments returned by Sync
+type SyncResult struct {
+	// Comments are ordered by the time of their latest change
+	Comments []domain.Comment
+	// Complete is false when there were more than SyncLimit changes and only the oldest ones are returned
+	Complete bool
+	// Next is the position of the last returned comment, the next Sync resumes after it when Complete is false
+	Next domain.SyncCursor
+}
+
+// Sync returns the comments of the post changed at or after the cursor time, in the order of their latest change,
+// a cursor with an id resumes after that comment. Deleted comments are returned as tombstones and hidden ones masked.
+func (s Service) Sync(ctx context.Context, postID string, after domain.SyncCursor) (SyncResult, error) {
+	const op = "service.comment.sync"
+	log := s.log.With(slog.String("op", op))
+
+	// the storage orders and limits by the change time, one more comment tells whether the changes were cut
+	comments, err := s.provider.ListPostCommentsChangedSince(ctx, postID, after, SyncLimit+1)
+	if err != nil {
+		return SyncResult{}, handleErr(log, op, err)
+	}
+
+	result := SyncResult{Complete: len(comments) <= SyncLimit, Next: after}
+	if !result.Complete {
+		comments = comments[:SyncLimit]
+	}
+	if len(comments) > 0 {
+		last := comments[len(comments)-1]
+		result.Next = domain.SyncCursor{ChangedAt: last.ChangedAt(), ID: last.ID}
+	}
+	result.Comments = maskComments(comments)
+
+	return result, nil
+}
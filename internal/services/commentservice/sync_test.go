@@ -0,0 +1,95 @@
+package commentservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_Sync(t *testing.T) {
+	s := newSuite(t)
+	defer s.mockProvider.AssertExpectations(t)
+
+	since := domain.SyncCursor{ChangedAt: time.Now().Add(-time.Hour)}
+	deletedAt := since.ChangedAt.Add(30 * time.Minute)
+	s.mockProvider.On("ListPostCommentsChangedSince", mock.Anything, "p1", since, int64(SyncLimit+1)).Return([]domain.Comment{
+		{ID: "2", Body: "edited", CreatedAt: since.ChangedAt.Add(-time.Hour), UpdatedAt: since.ChangedAt.Add(10 * time.Minute)},
+		{ID: "1", Body: "deleted", CreatedAt: since.ChangedAt, UpdatedAt: since.ChangedAt, DeletedAt: &deletedAt},
+	}, nil)
+
+	result, err := s.Service.Sync(context.Background(), "p1", since)
+	assert.Nil(t, err)
+	assert.True(t, result.Complete)
+	assert.Equal(t, domain.SyncCursor{ChangedAt: deletedAt, ID: "1"}, result.Next)
+	if assert.Len(t, result.Comments, 2) {
+		assert.Equal(t, "2", result.Comments[0].ID)
+		assert.Equal(t, "1", result.Comments[1].ID)
+		assert.Equal(t, domain.TombstoneBody, result.Comments[1].Body)
+	}
+}
+
+func TestService_Sync_NoChanges(t *testing.T) {
+	s := newSuite(t)
+
+	since := domain.SyncCursor{ChangedAt: time.Now().Add(-time.Hour)}
+	s.mockProvider.On("ListPostCommentsChangedSince", mock.Anything, "p1", since, int64(SyncLimit+1)).Return(nil, nil)
+
+	result, err := s.Service.Sync(context.Background(), "p1", since)
+	assert.Nil(t, err)
+	assert.True(t, result.Complete)
+	assert.Equal(t, since, result.Next)
+	assert.Empty(t, result.Comments)
+}
+
+func TestService_Sync_Incomplete(t *testing.T) {
+	s := newSuite(t)
+
+	// all the changes happened at the same time, the cursor tells them apart by id
+	changedAt := time.Now().Add(-time.Hour)
+	since := domain.SyncCursor{ChangedAt: changedAt}
+	changed := make([]domain.Comment, SyncLimit+1)
+	for i := range changed {
+		changed[i] = domain.Comment{ID: domain.NewID(), CreatedAt: changedAt, UpdatedAt: changedAt}
+	}
+	s.mockProvider.On("ListPostCommentsChangedSince", mock.Anything, "p1", since, int64(SyncLimit+1)).Return(changed, nil)
+
+	result, err := s.Service.Sync(context.Background(), "p1", since)
+	assert.Nil(t, err)
+	assert.False(t, result.Complete)
+	assert.Len(t, result.Comments, SyncLimit)
+	// the next sync resumes after the last returned change, not the cut one
+	assert.Equal(t, domain.SyncCursor{ChangedAt: changedAt, ID: changed[SyncLimit-1].ID}, result.Next)
+}
+
+func TestService_Sync_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		onList        error
+		expectedError error
+	}{
+		{
+			name:          "invalid post id",
+			onList:        domain.ErrInvalidID,
+			expectedError: domain.ErrInvalidID,
+		},
+		{
+			name:          "unexpected error",
+			onList:        assert.AnError,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newSuite(t)
+			s.mockProvider.On("ListPostCommentsChangedSince", mock.Anything, "p1", mock.Anything, mock.Anything).Return(nil, tc.onList)
+
+			_, err := s.Service.Sync(context.Background(), "p1", domain.SyncCursor{ChangedAt: time.Now()})
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ClubProvider is an autogenerated mock type for the ClubProvider type
+type ClubProvider struct {
+	mock.Mock
+}
+
+// CanManagePosts provides a mock function with given fields: ctx, clubID, userID
+func (_m *ClubProvider) CanManagePosts(ctx context.Context, clubID int64, userID int64) (bool, error) {
+	ret := _m.Called(ctx, clubID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanManagePosts")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (bool, error)); ok {
+		return rf(ctx, clubID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) bool); ok {
+		r0 = rf(ctx, clubID, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, clubID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsMember provides a mock function with given fields: ctx, clubID, userID
+func (_m *ClubProvider) IsMember(ctx context.Context, clubID int64, userID int64) (bool, error) {
+	ret := _m.Called(ctx, clubID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsMember")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (bool, error)); ok {
+		return rf(ctx, clubID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) bool); ok {
+		r0 = rf(ctx, clubID, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, clubID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewClubProvider creates a new instance of ClubProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClubProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ClubProvider {
+	mock := &ClubProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
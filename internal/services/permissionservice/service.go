@@ -0,0 +1,130 @@
+package permissionservice
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+)
+
+// Service decides who may moderate the comments of a post:
+// platform moderators and admins, or members of the club that owns the post with the permission to manage posts
+type Service struct {
+	log          *slog.Logger
+	roleProvider RoleProvider
+	postProvider PostProvider
+	clubProvider ClubProvider
+}
+
+//go:generate mockery --name RoleProvider
+type RoleProvider interface {
+	IsModerator(ctx context.Context, userID int64) (bool, error)
+}
+
+//go:generate mockery --name PostProvider
+type PostProvider interface {
+	GetPostClubID(ctx context.Context, postID string) (int64, error)
+	CanViewPost(ctx context.Context, postID string, userID int64) (bool, error)
+}
+
+//go:generate mockery --name ClubProvider
+type ClubProvider interface {
+	CanManagePosts(ctx context.Context, clubID, userID int64) (bool, error)
+	IsMember(ctx context.Context, clubID, userID int64) (bool, error)
+}
+
+func New(log *slog.Logger, roleProvider RoleProvider, postProvider PostProvider, clubProvider ClubProvider) Service {
+	return Service{
+		log:          log,
+		roleProvider: roleProvider,
+		postProvider: postProvider,
+		clubProvider: clubProvider,
+	}
+}
+
+func (s Service) CanModerate(ctx context.Context, userID int64, postID string) (bool, error) {
+	const op = "service.permission.can_moderate"
+	log := s.log.With(slog.String("op", op))
+
+	isModerator, err := s.roleProvider.IsModerator(ctx, userID)
+	if err != nil {
+		// the club permission is still checked, the user service being down must not lock out club admins
+		log.Warn("role provider failed", logger.Err(err))
+	}
+	if isModerator {
+		return true, nil
+	}
+
+	clubID, err := s.postProvider.GetPostClubID(ctx, postID)
+	if err != nil {
+		return false, handleErr(log, op, err)
+	}
+
+	allowed, err := s.clubProvider.CanManagePosts(ctx, clubID, userID)
+	if err != nil {
+		return false, handleErr(log, op, err)
+	}
+
+	return allowed, nil
+}
+
+// CanModerateClub tells whether the user may moderate the comments of all the posts of the club
+func (s Service) CanModerateClub(ctx context.Context, userID int64, clubID int64) (bool, error) {
+	const op = "service.permission.can_moderate_club"
+	log := s.log.With(slog.String("op", op))
+
+	isModerator, err := s.roleProvider.IsModerator(ctx, userID)
+	if err != nil {
+		log.Warn("role provider failed", logger.Err(err))
+	}
+	if isModerator {
+		return true, nil
+	}
+
+	allowed, err := s.clubProvider.CanManagePosts(ctx, clubID, userID)
+	if err != nil {
+		return false, handleErr(log, op, err)
+	}
+
+	return allowed, nil
+}
+
+// CanViewPost reports whether the user may see the post and subscribe to its comments
+func (s Service) CanViewPost(ctx context.Context, userID int64, postID string) (bool, error) {
+	const op = "service.permission.can_view_post"
+	log := s.log.With(slog.String("op", op))
+
+	allowed, err := s.postProvider.CanViewPost(ctx, postID, userID)
+	if err != nil {
+		return false, handleErr(log, op, err)
+	}
+
+	return allowed, nil
+}
+
+// CanViewClub reports whether the user may subscribe to the club channel, only club members can
+func (s Service) CanViewClub(ctx context.Context, userID int64, clubID int64) (bool, error) {
+	const op = "service.permission.can_view_club"
+	log := s.log.With(slog.String("op", op))
+
+	allowed, err := s.clubProvider.IsMember(ctx, clubID, userID)
+	if err != nil {
+		return false, handleErr(log, op, err)
+	}
+
+	return allowed, nil
+}
+
+func handleErr(log *slog.Logger, op string, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound), errors.Is(err, domain.ErrClubNotFound):
+		return err
+	case errors.Is(err, domain.ErrInvalidArg):
+		return err
+	default:
+		log.Error(op, logger.Err(err))
+		return domain.ErrInternal
+	}
+}
@@ -0,0 +1,173 @@
+package permissionservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/permissionservice/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type Suite struct {
+	Service      Service
+	roleProvider *mocks.RoleProvider
+	postProvider *mocks.PostProvider
+	clubProvider *mocks.ClubProvider
+}
+
+func NewSuite(t *testing.T) *Suite {
+	s := &Suite{
+		roleProvider: mocks.NewRoleProvider(t),
+		postProvider: mocks.NewPostProvider(t),
+		clubProvider: mocks.NewClubProvider(t),
+	}
+	s.Service = New(logger.Plug(), s.roleProvider, s.postProvider, s.clubProvider)
+	return s
+}
+
+func TestService_CanModerate(t *testing.T) {
+	tests := []struct {
+		name            string
+		isModerator     bool
+		onIsModerator   error
+		onGetPostClubID error
+		canManagePosts  bool
+		onCanManage     error
+		expected        bool
+		expectedError   error
+	}{
+		{
+			name:        "platform moderator",
+			isModerator: true,
+			expected:    true,
+		},
+		{
+			name:           "club admin",
+			canManagePosts: true,
+			expected:       true,
+		},
+		{
+			name:           "role provider fails, club admin",
+			onIsModerator:  assert.AnError,
+			canManagePosts: true,
+			expected:       true,
+		},
+		{
+			name:     "regular user",
+			expected: false,
+		},
+		{
+			name:            "post not found",
+			onGetPostClubID: domain.ErrPostNotFound,
+			expectedError:   domain.ErrPostNotFound,
+		},
+		{
+			name:          "unexpected error",
+			onCanManage:   assert.AnError,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSuite(t)
+
+			s.roleProvider.On("IsModerator", mock.Anything, int64(1)).Return(tt.isModerator, tt.onIsModerator)
+			if !tt.isModerator {
+				s.postProvider.On("GetPostClubID", mock.Anything, "p1").Return(int64(10), tt.onGetPostClubID)
+				if tt.onGetPostClubID == nil {
+					s.clubProvider.On("CanManagePosts", mock.Anything, int64(10), int64(1)).Return(tt.canManagePosts, tt.onCanManage)
+				}
+			}
+
+			allowed, err := s.Service.CanModerate(context.Background(), 1, "p1")
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
+
+func TestService_CanModerateClub(t *testing.T) {
+	tests := []struct {
+		name           string
+		isModerator    bool
+		canManagePosts bool
+		onCanManage    error
+		expected       bool
+		expectedError  error
+	}{
+		{name: "platform moderator", isModerator: true, expected: true},
+		{name: "club admin", canManagePosts: true, expected: true},
+		{name: "regular user", expected: false},
+		{name: "club not found", onCanManage: domain.ErrClubNotFound, expectedError: domain.ErrClubNotFound},
+		{name: "unexpected error", onCanManage: assert.AnError, expectedError: domain.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSuite(t)
+
+			s.roleProvider.On("IsModerator", mock.Anything, int64(1)).Return(tt.isModerator, nil)
+			if !tt.isModerator {
+				s.clubProvider.On("CanManagePosts", mock.Anything, int64(10), int64(1)).Return(tt.canManagePosts, tt.onCanManage)
+			}
+
+			allowed, err := s.Service.CanModerateClub(context.Background(), 1, 10)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
+
+func TestService_CanViewPost(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowed       bool
+		onCanView     error
+		expectedError error
+	}{
+		{name: "visible", allowed: true},
+		{name: "not visible", allowed: false},
+		{name: "post not found", onCanView: domain.ErrPostNotFound, expectedError: domain.ErrPostNotFound},
+		{name: "unexpected error", onCanView: assert.AnError, expectedError: domain.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSuite(t)
+			s.postProvider.On("CanViewPost", mock.Anything, "p1", int64(1)).Return(tt.allowed, tt.onCanView)
+
+			allowed, err := s.Service.CanViewPost(context.Background(), 1, "p1")
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.allowed, allowed)
+		})
+	}
+}
+
+func TestService_CanViewClub(t *testing.T) {
+	tests := []struct {
+		name          string
+		isMember      bool
+		onIsMember    error
+		expectedError error
+	}{
+		{name: "member", isMember: true},
+		{name: "not a member", isMember: false},
+		{name: "club not found", onIsMember: domain.ErrClubNotFound, expectedError: domain.ErrClubNotFound},
+		{name: "unexpected error", onIsMember: assert.AnError, expectedError: domain.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSuite(t)
+			s.clubProvider.On("IsMember", mock.Anything, int64(10), int64(1)).Return(tt.isMember, tt.onIsMember)
+
+			allowed, err := s.Service.CanViewClub(context.Background(), 1, 10)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.isMember, allowed)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BanStorage is an autogenerated mock type for the BanStorage type
+type BanStorage struct {
+	mock.Mock
+}
+
+// ListActiveBans provides a mock function with given fields: ctx, now
+func (_m *BanStorage) ListActiveBans(ctx context.Context, now time.Time) ([]domain.Ban, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveBans")
+	}
+
+	var r0 []domain.Ban
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.Ban, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.Ban); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Ban)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveBan provides a mock function with given fields: ctx, ban
+func (_m *BanStorage) SaveBan(ctx context.Context, ban domain.Ban) error {
+	ret := _m.Called(ctx, ban)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Ban) error); ok {
+		r0 = rf(ctx, ban)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewBanStorage creates a new instance of BanStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBanStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BanStorage {
+	mock := &BanStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,42 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// Disconnector is an autogenerated mock type for the Disconnector type
+type Disconnector struct {
+	mock.Mock
+}
+
+// DisconnectUser provides a mock function with given fields: userID
+func (_m *Disconnector) DisconnectUser(userID int64) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisconnectUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDisconnector creates a new instance of Disconnector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDisconnector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Disconnector {
+	mock := &Disconnector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
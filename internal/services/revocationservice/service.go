@@ -0,0 +1,207 @@
+package revocationservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+)
+
+// Service keeps the bans of the users in memory, so the checks on every connection and change do not hit the database.
+// The bans are persisted and periodically reloaded, which shares them between the instances.
+type Service struct {
+	log          *slog.Logger
+	storage      BanStorage
+	disconnector Disconnector
+	syncInterval time.Duration
+
+	mu   sync.RWMutex
+	bans map[int64]domain.Ban
+
+	started atomic.Bool
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+//go:generate mockery --name BanStorage
+type BanStorage interface {
+	SaveBan(ctx context.Context, ban domain.Ban) error
+	ListActiveBans(ctx context.Context, now time.Time) ([]domain.Ban, error)
+}
+
+// Disconnector closes the active realtime connections of the user
+//
+//go:generate mockery --name Disconnector
+type Disconnector interface {
+	DisconnectUser(userID int64) error
+}
+
+// New creates the revocation store, the storage is required so the other instances learn of the bans
+func New(log *slog.Logger, storage BanStorage, syncInterval time.Duration) *Service {
+	return &Service{
+		log:          log,
+		storage:      storage,
+		syncInterval: syncInterval,
+		bans:         make(map[int64]domain.Ban),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+// SetDisconnector sets who closes the connections of the banned users,
+// it must be called before the service starts consuming bans
+func (s *Service) SetDisconnector(disconnector Disconnector) {
+	s.disconnector = disconnector
+}
+
+// IsRevoked reports whether the user is banned
+func (s *Service) IsRevoked(_ context.Context, userID int64) bool {
+	s.mu.RLock()
+	ban, ok := s.bans[userID]
+	s.mu.RUnlock()
+
+	return ok && ban.IsActive(time.Now())
+}
+
+// Ban revokes the access of the user and disconnects all of its active connections
+func (s *Service) Ban(ctx context.Context, ban domain.Ban) error {
+	const op = "service.revocation.ban"
+	log := s.log.With(slog.String("op", op), slog.Int64("user_id", ban.UserID))
+
+	if ban.UserID <= 0 {
+		return fmt.Errorf("%w: user_id is required", domain.ErrInvalidArg)
+	}
+	if ban.BannedAt.IsZero() {
+		ban.BannedAt = time.Now().UTC()
+	}
+	if !ban.IsActive(time.Now()) {
+		log.Info("skipping expired ban")
+		return nil
+	}
+
+	s.mu.Lock()
+	s.bans[ban.UserID] = ban
+	s.mu.Unlock()
+
+	if s.disconnector != nil {
+		if err := s.disconnector.DisconnectUser(ban.UserID); err != nil {
+			log.Error("failed to disconnect banned user", logger.Err(err))
+		}
+	}
+
+	if err := s.storage.SaveBan(ctx, ban); err != nil {
+		return handleErr(log, op, err)
+	}
+
+	log.Info("user banned")
+
+	return nil
+}
+
+// Sync reloads the persisted bans, the bans recorded by this instance that are not persisted yet are kept
+func (s *Service) Sync(ctx context.Context) error {
+	const op = "service.revocation.sync"
+	log := s.log.With(slog.String("op", op))
+
+	now := time.Now()
+	persisted, err := s.storage.ListActiveBans(ctx, now)
+	if err != nil {
+		return handleErr(log, op, err)
+	}
+
+	bans := make(map[int64]domain.Ban, len(persisted))
+	for _, ban := range persisted {
+		bans[ban.UserID] = ban
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for userID, ban := range s.bans {
+		if _, ok := bans[userID]; !ok && ban.IsActive(now) {
+			bans[userID] = ban
+		}
+	}
+	s.bans = bans
+
+	return nil
+}
+
+// Start loads the persisted bans and reloads them every sync interval in the background
+func (s *Service) Start(ctx context.Context, _ func(error)) {
+	const op = "service.revocation.start"
+	log := s.log.With(slog.String("op", op))
+
+	s.started.Store(true)
+
+	if err := s.Sync(ctx); err != nil {
+		log.Error("failed to load bans", logger.Err(err))
+	}
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.syncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.sync()
+			}
+		}
+	}()
+}
+
+func (s *Service) sync() {
+	const op = "service.revocation.sync_job"
+	log := s.log.With(slog.String("op", op))
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.syncInterval)
+	defer cancel()
+
+	if err := s.Sync(ctx); err != nil {
+		log.Error("failed to reload bans", logger.Err(err))
+	}
+}
+
+// Stop stops the background reload and waits for the running one to finish
+func (s *Service) Stop(ctx context.Context) error {
+	const op = "service.revocation.stop"
+
+	s.once.Do(func() { close(s.stop) })
+
+	if !s.started.Load() {
+		return nil
+	}
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s: %w", op, ctx.Err())
+	}
+}
+
+func handleErr(log *slog.Logger, op string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidArg):
+		return err
+	default:
+		log.Error(op, logger.Err(err))
+		return domain.ErrInternal
+	}
+}
@@ -0,0 +1,153 @@
+package revocationservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/revocationservice/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type Suite struct {
+	Service      *Service
+	storage      *mocks.BanStorage
+	disconnector *mocks.Disconnector
+}
+
+func NewSuite(t *testing.T) *Suite {
+	s := &Suite{
+		storage:      mocks.NewBanStorage(t),
+		disconnector: mocks.NewDisconnector(t),
+	}
+	s.Service = New(logger.Plug(), s.storage, time.Minute)
+	s.Service.SetDisconnector(s.disconnector)
+	return s
+}
+
+func TestService_Ban(t *testing.T) {
+	s := NewSuite(t)
+	ban := domain.Ban{UserID: 1, Reason: "spam", BannedAt: time.Now().UTC()}
+
+	s.disconnector.On("DisconnectUser", int64(1)).Return(nil)
+	s.storage.On("SaveBan", mock.Anything, ban).Return(nil)
+
+	err := s.Service.Ban(context.Background(), ban)
+
+	require.NoError(t, err)
+	assert.True(t, s.Service.IsRevoked(context.Background(), 1))
+	assert.False(t, s.Service.IsRevoked(context.Background(), 2))
+}
+
+func TestService_Ban_FailPath(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name            string
+		ban             domain.Ban
+		mock            func(s *Suite)
+		expectedError   error
+		expectedRevoked bool
+	}{
+		{
+			name:          "missing user id",
+			ban:           domain.Ban{},
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name: "expired ban",
+			ban:  domain.Ban{UserID: 1, Until: &past},
+		},
+		{
+			name: "storage error",
+			ban:  domain.Ban{UserID: 1},
+			mock: func(s *Suite) {
+				s.disconnector.On("DisconnectUser", int64(1)).Return(nil)
+				s.storage.On("SaveBan", mock.Anything, mock.Anything).Return(errors.New("unexpected error"))
+			},
+			expectedError:   domain.ErrInternal,
+			expectedRevoked: true,
+		},
+		{
+			name: "disconnect error",
+			ban:  domain.Ban{UserID: 1},
+			mock: func(s *Suite) {
+				s.disconnector.On("DisconnectUser", int64(1)).Return(errors.New("unexpected error"))
+				s.storage.On("SaveBan", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedRevoked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSuite(t)
+			if tt.mock != nil {
+				tt.mock(s)
+			}
+
+			err := s.Service.Ban(context.Background(), tt.ban)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedRevoked, s.Service.IsRevoked(context.Background(), tt.ban.UserID))
+		})
+	}
+}
+
+func TestService_IsRevoked_ExpiredBan(t *testing.T) {
+	s := NewSuite(t)
+	until := time.Now().Add(50 * time.Millisecond)
+
+	s.disconnector.On("DisconnectUser", int64(1)).Return(nil)
+	s.storage.On("SaveBan", mock.Anything, mock.Anything).Return(nil)
+
+	require.NoError(t, s.Service.Ban(context.Background(), domain.Ban{UserID: 1, Until: &until}))
+	assert.True(t, s.Service.IsRevoked(context.Background(), 1))
+
+	assert.Eventually(t, func() bool {
+		return !s.Service.IsRevoked(context.Background(), 1)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestService_Sync(t *testing.T) {
+	s := NewSuite(t)
+
+	s.disconnector.On("DisconnectUser", int64(2)).Return(nil)
+	s.storage.On("SaveBan", mock.Anything, mock.Anything).Return(errors.New("unexpected error")).Once()
+	_ = s.Service.Ban(context.Background(), domain.Ban{UserID: 2})
+
+	s.storage.On("ListActiveBans", mock.Anything, mock.Anything).Return([]domain.Ban{{UserID: 1}}, nil)
+
+	err := s.Service.Sync(context.Background())
+
+	require.NoError(t, err)
+	assert.True(t, s.Service.IsRevoked(context.Background(), 1))
+	// the ban that failed to persist is kept until the next successful save
+	assert.True(t, s.Service.IsRevoked(context.Background(), 2))
+}
+
+func TestService_Sync_FailPath(t *testing.T) {
+	s := NewSuite(t)
+	s.storage.On("ListActiveBans", mock.Anything, mock.Anything).Return(nil, errors.New("unexpected error"))
+
+	err := s.Service.Sync(context.Background())
+
+	assert.ErrorIs(t, err, domain.ErrInternal)
+}
+
+func TestService_StartAndStop(t *testing.T) {
+	s := NewSuite(t)
+	s.storage.On("ListActiveBans", mock.Anything, mock.Anything).Return([]domain.Ban{{UserID: 1}}, nil)
+
+	s.Service.Start(context.Background(), nil)
+	assert.True(t, s.Service.IsRevoked(context.Background(), 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, s.Service.Stop(ctx))
+}
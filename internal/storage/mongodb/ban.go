@@ -0,0 +1,56 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/storage/mongodb/dao"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveBan stores the ban of the user, replacing the previous ban of the user
+func (s *Storage) SaveBan(ctx context.Context, ban domain.Ban) error {
+	const op = "storage.mongodb.save_ban"
+
+	filter := bson.M{"_id": ban.UserID}
+
+	_, err := s.banCollection.ReplaceOne(ctx, filter, dao.BanFromDomain(ban), options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListActiveBans returns the permanent bans and the bans that end after now
+func (s *Storage) ListActiveBans(ctx context.Context, now time.Time) ([]domain.Ban, error) {
+	const op = "storage.mongodb.list_active_bans"
+
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"until": nil},
+			bson.M{"until": bson.M{"$gt": now}},
+		},
+	}
+
+	cursor, err := s.banCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer cursor.Close(ctx)
+
+	var bans []dao.Ban
+	if err = cursor.All(ctx, &bans); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.Ban, 0, len(bans))
+	for _, ban := range bans {
+		result = append(result, ban.ToDomain())
+	}
+
+	return result, nil
+}
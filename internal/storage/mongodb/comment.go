@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
+
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/storage/mongodb/dao"
 	"go.mongodb.org/mongo-driver/bson"
@@ -50,14 +53,153 @@ func (s *Storage) ListPostComments(ctx context.Context, postID string, filters d
 		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: failed to convert postID to ObjectID: %w", op, err)
 	}
 
-	query := bson.M{"post_id": objectID}
+	// only top-level comments are listed for a post, replies are fetched per thread
+	query := bson.M{"post_id": objectID, "parent_id": nil, "$or": visibleQuery()}
+
+	comments, paginationMetadata, err := s.listComments(ctx, query, filters)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: %w", op, err)
+	}
+	// an empty page past the cursor is a valid answer, only an empty post is reported as not found
+	if !filters.HasCursor() && paginationMetadata.TotalRecords == 0 {
+		return nil, domain.PaginationMetadata{}, domain.ErrCommentNotFound
+	}
+
+	return comments, paginationMetadata, nil
+}
+
+func (s *Storage) ListCommentReplies(ctx context.Context, parentID string, filters domain.Filter) (
+	[]domain.Comment,
+	domain.PaginationMetadata,
+	error,
+) {
+	const op = "storage.mongodb.list_comment_replies"
+
+	objectID, err := primitive.ObjectIDFromHex(parentID)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return nil, domain.PaginationMetadata{}, domain.ErrInvalidID
+		}
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: failed to convert parentID to ObjectID: %w", op, err)
+	}
+
+	comments, paginationMetadata, err := s.listComments(ctx, bson.M{"parent_id": objectID, "$or": visibleQuery()}, filters)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return comments, paginationMetadata, nil
+}
+
+// ListPostCommentsChangedSince returns the comments of the post, replies included, that were created, updated,
+// deleted or hidden at or after the cursor time, ordered by the time of their latest change, the same key as
+// domain.Comment.ChangedAt, and their id, so the first limit comments are the oldest changes.
+// A cursor with an id skips the comments up to and including it. At most limit comments are returned.
+func (s *Storage) ListPostCommentsChangedSince(ctx context.Context, postID string, after domain.SyncCursor, limit int64) ([]domain.Comment, error) {
+	const op = "storage.mongodb.list_post_comments_changed_since"
+
+	objectID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return nil, domain.ErrInvalidID
+		}
+		return nil, fmt.Errorf("%s: failed to convert postID to ObjectID: %w", op, err)
+	}
+
+	since := after.ChangedAt
+	changedAfter := bson.M{"changed_at": bson.M{"$gte": since}}
+	if after.ID != "" {
+		afterID, err := primitive.ObjectIDFromHex(after.ID)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		// the comments changed at the same time are ordered by id, the page resumes after the last returned one
+		changedAfter = bson.M{"$or": bson.A{
+			bson.M{"changed_at": bson.M{"$gt": since}},
+			bson.M{"changed_at": since, "_id": bson.M{"$gt": afterID}},
+		}}
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{
+			"post_id": objectID,
+			"$or": bson.A{
+				bson.M{"updated_at": bson.M{"$gte": since}},
+				bson.M{"deleted_at": bson.M{"$gte": since}},
+				bson.M{"hidden_at": bson.M{"$gte": since}},
+			},
+		}},
+		// $max ignores the missing deleted_at and hidden_at
+		bson.M{"$addFields": bson.M{"changed_at": bson.M{"$max": bson.A{"$created_at", "$updated_at", "$deleted_at", "$hidden_at"}}}},
+		bson.M{"$match": changedAfter},
+		bson.M{"$sort": bson.D{{Key: "changed_at", Value: 1}, {Key: "_id", Value: 1}}},
+		bson.M{"$limit": limit},
+	}
+
+	cursor, err := s.commentCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to aggregate documents: %w", op, err)
+	}
+
+	var comments []dao.Comment
+	err = cursor.All(ctx, &comments)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to decode documents: %w", op, err)
+	}
+
+	return dao.CommentsToDomain(comments), nil
+}
+
+// ListPendingComments returns the comments of the post, replies included, that wait for a moderator to review them
+func (s *Storage) ListPendingComments(ctx context.Context, postID string, filters domain.Filter) (
+	[]domain.Comment,
+	domain.PaginationMetadata,
+	error,
+) {
+	const op = "storage.mongodb.list_pending_comments"
+
+	objectID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return nil, domain.PaginationMetadata{}, domain.ErrInvalidID
+		}
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: failed to convert postID to ObjectID: %w", op, err)
+	}
+
+	query := bson.M{"post_id": objectID, "moderation.status": domain.ModerationPending, "deleted_at": nil}
+
+	comments, paginationMetadata, err := s.listComments(ctx, query, filters)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return comments, paginationMetadata, nil
+}
+
+// visibleQuery matches comments that are not deleted or are deleted but still have replies and are shown as tombstones
+func visibleQuery() bson.A {
+	return bson.A{
+		bson.M{"deleted_at": nil},
+		bson.M{"reply_count": bson.M{"$gt": 0}},
+	}
+}
+
+// listComments returns the page of comments matching the query described by filters
+func (s *Storage) listComments(ctx context.Context, query bson.M, filters domain.Filter) (
+	[]domain.Comment,
+	domain.PaginationMetadata,
+	error,
+) {
+	if filters.HasCursor() {
+		return s.listCommentsByCursor(ctx, query, filters)
+	}
 
 	totalRecords, err := s.commentCollection.CountDocuments(ctx, query)
 	if err != nil {
-		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: failed to count documents: %w", op, err)
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("failed to count documents: %w", err)
 	}
 	if totalRecords == 0 {
-		return nil, domain.PaginationMetadata{}, domain.ErrCommentNotFound
+		return []domain.Comment{}, domain.PaginationMetadata{}, nil
 	}
 
 	if filters.SortBy == "" {
@@ -72,18 +214,98 @@ func (s *Storage) ListPostComments(ctx context.Context, postID string, filters d
 
 	cursor, err := s.commentCollection.Find(ctx, query, opts)
 	if err != nil {
-		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: failed to find documents: %w", op, err)
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("failed to find documents: %w", err)
 	}
 
 	var comments []dao.Comment
 	err = cursor.All(ctx, &comments)
 	if err != nil {
-		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: failed to decode documents: %w", op, err)
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("failed to decode documents: %w", err)
 	}
 
+	domainComments := dao.CommentsToDomain(comments)
 	paginationMetadata := domain.CalculatePaginationMetadata(int32(totalRecords), filters.Page, filters.PageSize)
 
-	return dao.CommentsToDomain(comments), paginationMetadata, nil
+	// let page/offset clients switch to the cursor for the following pages
+	if filters.SortBy == domain.SortByCreatedAt && len(domainComments) > 0 && filters.Page < paginationMetadata.LastPage {
+		paginationMetadata.NextCursor = cursorOf(domainComments[len(domainComments)-1], domain.CursorNext)
+	}
+
+	return domainComments, paginationMetadata, nil
+}
+
+// listCommentsByCursor returns the page of comments next to the cursor position,
+// comments are ordered by created_at and _id, so new comments do not shift the pages
+func (s *Storage) listCommentsByCursor(ctx context.Context, query bson.M, filters domain.Filter) (
+	[]domain.Comment,
+	domain.PaginationMetadata,
+	error,
+) {
+	position := filters.Cursor
+	objectID, err := primitive.ObjectIDFromHex(position.ID)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, domain.ErrInvalidCursor
+	}
+
+	// the previous page is read in the reverse order starting from the cursor and flipped afterwards
+	backward := position.Direction == domain.CursorPrev
+	order := filters.SortOrder.Mongo()
+	if backward {
+		order = -order
+	}
+	operator := "$gt"
+	if order < 0 {
+		operator = "$lt"
+	}
+
+	keysetQuery := bson.M{"$or": bson.A{
+		bson.M{"created_at": bson.M{operator: position.CreatedAt}},
+		bson.M{"created_at": position.CreatedAt, "_id": bson.M{operator: objectID}},
+	}}
+
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "created_at", Value: order}, {Key: "_id", Value: order}})
+	// one more document tells whether there is a page after this one
+	opts.SetLimit(int64(filters.Limit()) + 1)
+
+	cursor, err := s.commentCollection.Find(ctx, bson.M{"$and": bson.A{query, keysetQuery}}, opts)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("failed to find documents: %w", err)
+	}
+
+	var comments []dao.Comment
+	err = cursor.All(ctx, &comments)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("failed to decode documents: %w", err)
+	}
+
+	hasMore := len(comments) > int(filters.Limit())
+	if hasMore {
+		comments = comments[:filters.Limit()]
+	}
+	if backward {
+		slices.Reverse(comments)
+	}
+
+	domainComments := dao.CommentsToDomain(comments)
+	paginationMetadata := domain.PaginationMetadata{PageSize: filters.PageSize}
+	if len(domainComments) == 0 {
+		return domainComments, paginationMetadata, nil
+	}
+
+	// the cursor itself points at a comment on the side the client came from
+	if !backward || hasMore {
+		paginationMetadata.PrevCursor = cursorOf(domainComments[0], domain.CursorPrev)
+	}
+	if backward || hasMore {
+		paginationMetadata.NextCursor = cursorOf(domainComments[len(domainComments)-1], domain.CursorNext)
+	}
+
+	return domainComments, paginationMetadata, nil
+}
+
+func cursorOf(comment domain.Comment, direction domain.CursorDirection) string {
+	return domain.Cursor{CreatedAt: comment.CreatedAt, ID: comment.ID, Direction: direction}.Encode()
 }
 
 func (s *Storage) CreateComment(ctx context.Context, domainComment domain.Comment) (domain.Comment, error) {
@@ -102,6 +324,13 @@ func (s *Storage) CreateComment(ctx context.Context, domainComment domain.Commen
 		return domain.Comment{}, fmt.Errorf("%s: failed to insert document: %w", op, err)
 	}
 
+	if comment.ParentID != nil {
+		_, err = s.commentCollection.UpdateByID(ctx, *comment.ParentID, bson.M{"$inc": bson.M{"reply_count": 1}})
+		if err != nil {
+			return domain.Comment{}, fmt.Errorf("%s: failed to increment parent reply count: %w", op, err)
+		}
+	}
+
 	err = s.commentCollection.FindOne(ctx, bson.M{"_id": result.InsertedID}).Decode(&comment)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
@@ -113,6 +342,169 @@ func (s *Storage) CreateComment(ctx context.Context, domainComment domain.Commen
 	return comment.ToDomain(), nil
 }
 
+// SoftDeleteComment marks the comment as deleted, the document is kept until it is purged
+func (s *Storage) SoftDeleteComment(ctx context.Context, id string, deletedBy int64, deletedAt time.Time) error {
+	const op = "storage.mongodb.soft_delete_comment"
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.ErrInvalidID
+		}
+		return fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	result, err := s.commentCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID, "deleted_at": nil},
+		bson.M{"$set": bson.M{"deleted_at": deletedAt, "deleted_by": deletedBy}},
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update document: %w", op, err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// RestoreComment removes the deletion mark of the comment
+func (s *Storage) RestoreComment(ctx context.Context, id string) error {
+	const op = "storage.mongodb.restore_comment"
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.ErrInvalidID
+		}
+		return fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	result, err := s.commentCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID, "deleted_at": bson.M{"$ne": nil}},
+		bson.M{
+			"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+			// restoring is a change of the comment, clients syncing missed events pick it up by updated_at
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update document: %w", op, err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// SetCommentHidden marks the comment as hidden by the moderator, a nil hiddenAt unhides it.
+// Both bump updated_at, clients syncing missed events pick up the unhidden comments by it.
+func (s *Storage) SetCommentHidden(ctx context.Context, id string, hiddenBy int64, hiddenAt *time.Time) error {
+	const op = "storage.mongodb.set_comment_hidden"
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.ErrInvalidID
+		}
+		return fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	update := bson.M{
+		"$unset": bson.M{"hidden_at": "", "hidden_by": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	}
+	if hiddenAt != nil {
+		update = bson.M{"$set": bson.M{"hidden_at": hiddenAt, "hidden_by": hiddenBy, "updated_at": *hiddenAt}}
+	}
+
+	result, err := s.commentCollection.UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update document: %w", op, err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// SetCommentModeration replaces the moderation verdict of the comment, e.g. once a moderator reviewed it.
+// It bumps updated_at, clients syncing missed events pick up the reviewed comments by it.
+func (s *Storage) SetCommentModeration(ctx context.Context, id string, moderation domain.Moderation) error {
+	const op = "storage.mongodb.set_comment_moderation"
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.ErrInvalidID
+		}
+		return fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	update := bson.M{"$set": bson.M{
+		"moderation": dao.Moderation{
+			Status:  string(moderation.Status),
+			Reasons: moderation.Reasons,
+		},
+		"updated_at": time.Now(),
+	}}
+
+	result, err := s.commentCollection.UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update document: %w", op, err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// PurgeDeletedComments hard deletes comments that were soft deleted before the given time.
+// Tombstones that still have replies are kept until their replies are purged.
+func (s *Storage) PurgeDeletedComments(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	const op = "storage.mongodb.purge_deleted_comments"
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	cursor, err := s.commentCollection.Find(
+		ctx,
+		bson.M{
+			"deleted_at": bson.M{"$lt": deletedBefore},
+			// comments stored before reply_count was introduced have no replies counted
+			"$or": bson.A{
+				bson.M{"reply_count": bson.M{"$lte": 0}},
+				bson.M{"reply_count": bson.M{"$exists": false}},
+			},
+		},
+		opts,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to find documents: %w", op, err)
+	}
+
+	var comments []dao.Comment
+	err = cursor.All(ctx, &comments)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to decode documents: %w", op, err)
+	}
+
+	var purged int64
+	for _, comment := range comments {
+		err = s.DeleteComment(ctx, comment.ID.Hex())
+		if err != nil {
+			return purged, fmt.Errorf("%s: %w", op, err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// DeleteComment removes the comment with its reactions and revisions and decrements the reply count of its parent
 func (s *Storage) DeleteComment(ctx context.Context, id string) error {
 	const op = "storage.mongodb.delete_comment"
 
@@ -124,11 +516,32 @@ func (s *Storage) DeleteComment(ctx context.Context, id string) error {
 		return fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
 	}
 
-	_, err = s.commentCollection.DeleteOne(ctx, bson.M{"_id": objectID})
+	var comment dao.Comment
+	err = s.commentCollection.FindOneAndDelete(ctx, bson.M{"_id": objectID}).Decode(&comment)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
 		return fmt.Errorf("%s: failed to delete document: %w", op, err)
 	}
 
+	if comment.ParentID != nil {
+		_, err = s.commentCollection.UpdateByID(ctx, *comment.ParentID, bson.M{"$inc": bson.M{"reply_count": -1}})
+		if err != nil {
+			return fmt.Errorf("%s: failed to decrement parent reply count: %w", op, err)
+		}
+	}
+
+	_, err = s.reactionCollection.DeleteMany(ctx, bson.M{"comment_id": objectID})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete reactions: %w", op, err)
+	}
+
+	_, err = s.revisionCollection.DeleteMany(ctx, bson.M{"comment_id": objectID})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete revisions: %w", op, err)
+	}
+
 	return nil
 }
 
@@ -143,10 +556,21 @@ func (s *Storage) UpdateComment(ctx context.Context, comment domain.Comment) (do
 		return domain.Comment{}, fmt.Errorf("%s failed to convert id to ObjectID: %w", op, err)
 	}
 
-	commentToUpdate, _ := dao.CommentFromDomain(comment)
-	commentToUpdate.ID = objectID
+	// only mutable fields are set, so counters maintained by other writers are not overwritten
+	update := bson.M{
+		"$set": bson.M{
+			"body":       comment.Body,
+			"edited":     comment.Edited,
+			"updated_at": comment.UpdatedAt,
+			"moderation": dao.Moderation{
+				Status:  string(comment.Moderation.Status),
+				Reasons: comment.Moderation.Reasons,
+			},
+			"mentions": comment.Mentions,
+		},
+	}
 
-	_, err = s.commentCollection.ReplaceOne(ctx, bson.M{"_id": objectID}, commentToUpdate)
+	_, err = s.commentCollection.UpdateByID(ctx, objectID, update)
 	if err != nil {
 		return domain.Comment{}, fmt.Errorf("%s failed to update document: %w", op, err)
 	}
@@ -0,0 +1,36 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+)
+
+type Ban struct {
+	UserID   int64      `bson:"_id"`
+	Reason   string     `bson:"reason,omitempty"`
+	Until    *time.Time `bson:"until,omitempty"`
+	BannedAt time.Time  `bson:"banned_at"`
+}
+
+func (b *Ban) ToDomain() domain.Ban {
+	if b == nil {
+		return domain.Ban{}
+	}
+
+	return domain.Ban{
+		UserID:   b.UserID,
+		Reason:   b.Reason,
+		Until:    b.Until,
+		BannedAt: b.BannedAt,
+	}
+}
+
+func BanFromDomain(d domain.Ban) Ban {
+	return Ban{
+		UserID:   d.UserID,
+		Reason:   d.Reason,
+		Until:    d.Until,
+		BannedAt: d.BannedAt,
+	}
+}
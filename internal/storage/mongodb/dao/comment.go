@@ -7,12 +7,28 @@ import (
 )
 
 type Comment struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id"`
-	PostID    primitive.ObjectID `json:"post_id" bson:"post_id"`
-	User      User               `json:"user" bson:"user"`
-	Body      string             `json:"body" bson:"body"`
-	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+	ID         primitive.ObjectID  `json:"id" bson:"_id"`
+	PostID     primitive.ObjectID  `json:"post_id" bson:"post_id"`
+	ParentID   *primitive.ObjectID `json:"parent_id,omitempty" bson:"parent_id,omitempty"`
+	Depth      int32               `json:"depth" bson:"depth"`
+	ReplyCount int32               `json:"reply_count" bson:"reply_count"`
+	User       User                `json:"user" bson:"user"`
+	Body       string              `json:"body" bson:"body"`
+	Edited     bool                `json:"edited" bson:"edited"`
+	Reactions  map[string]int32    `json:"reactions,omitempty" bson:"reactions,omitempty"`
+	CreatedAt  time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at" bson:"updated_at"`
+	DeletedAt  *time.Time          `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	DeletedBy  int64               `json:"deleted_by,omitempty" bson:"deleted_by,omitempty"`
+	HiddenAt   *time.Time          `json:"hidden_at,omitempty" bson:"hidden_at,omitempty"`
+	HiddenBy   int64               `json:"hidden_by,omitempty" bson:"hidden_by,omitempty"`
+	Moderation Moderation          `json:"moderation" bson:"moderation"`
+	Mentions   []int64             `json:"mentions,omitempty" bson:"mentions,omitempty"`
+}
+
+type Moderation struct {
+	Status  string   `json:"status" bson:"status"`
+	Reasons []string `json:"reasons,omitempty" bson:"reasons,omitempty"`
 }
 
 func (c *Comment) ToDomain() domain.Comment {
@@ -20,13 +36,32 @@ func (c *Comment) ToDomain() domain.Comment {
 		return domain.Comment{}
 	}
 
+	var parentID string
+	if c.ParentID != nil {
+		parentID = c.ParentID.Hex()
+	}
+
 	return domain.Comment{
-		ID:        c.ID.Hex(),
-		PostID:    c.PostID.Hex(),
-		User:      c.User.ToDomain(),
-		Body:      c.Body,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: c.UpdatedAt,
+		ID:         c.ID.Hex(),
+		PostID:     c.PostID.Hex(),
+		ParentID:   parentID,
+		Depth:      c.Depth,
+		ReplyCount: c.ReplyCount,
+		User:       c.User.ToDomain(),
+		Body:       c.Body,
+		Edited:     c.Edited,
+		Reactions:  c.Reactions,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+		DeletedAt:  c.DeletedAt,
+		DeletedBy:  c.DeletedBy,
+		HiddenAt:   c.HiddenAt,
+		HiddenBy:   c.HiddenBy,
+		Moderation: domain.Moderation{
+			Status:  domain.ModerationStatus(c.Moderation.Status),
+			Reasons: c.Moderation.Reasons,
+		},
+		Mentions: c.Mentions,
 	}
 }
 
@@ -40,13 +75,36 @@ func CommentFromDomain(d domain.Comment) (Comment, error) {
 		return Comment{}, err
 	}
 
+	var parentID *primitive.ObjectID
+	if d.ParentID != "" {
+		id, err := primitive.ObjectIDFromHex(d.ParentID)
+		if err != nil {
+			return Comment{}, err
+		}
+		parentID = &id
+	}
+
 	return Comment{
-		ID:        objectID,
-		PostID:    postID,
-		User:      UserFromDomain(d.User),
-		Body:      d.Body,
-		CreatedAt: d.CreatedAt,
-		UpdatedAt: d.UpdatedAt,
+		ID:         objectID,
+		PostID:     postID,
+		ParentID:   parentID,
+		Depth:      d.Depth,
+		ReplyCount: d.ReplyCount,
+		User:       UserFromDomain(d.User),
+		Body:       d.Body,
+		Edited:     d.Edited,
+		Reactions:  d.Reactions,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+		DeletedAt:  d.DeletedAt,
+		DeletedBy:  d.DeletedBy,
+		HiddenAt:   d.HiddenAt,
+		HiddenBy:   d.HiddenBy,
+		Moderation: Moderation{
+			Status:  string(d.Moderation.Status),
+			Reasons: d.Moderation.Reasons,
+		},
+		Mentions: d.Mentions,
 	}, nil
 }
 
@@ -0,0 +1,60 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OutboxMessage struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	Exchange      string             `json:"exchange" bson:"exchange"`
+	RoutingKey    string             `json:"routing_key" bson:"routing_key"`
+	Payload       []byte             `json:"payload" bson:"payload"`
+	Status        string             `json:"status" bson:"status"`
+	Attempts      int                `json:"attempts" bson:"attempts"`
+	LastError     string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	NextAttemptAt time.Time          `json:"next_attempt_at" bson:"next_attempt_at"`
+	SentAt        *time.Time         `json:"sent_at,omitempty" bson:"sent_at,omitempty"`
+}
+
+func (m *OutboxMessage) ToDomain() domain.OutboxMessage {
+	if m == nil {
+		return domain.OutboxMessage{}
+	}
+
+	return domain.OutboxMessage{
+		ID:            m.ID.Hex(),
+		Exchange:      m.Exchange,
+		RoutingKey:    m.RoutingKey,
+		Payload:       m.Payload,
+		Status:        domain.OutboxStatus(m.Status),
+		Attempts:      m.Attempts,
+		LastError:     m.LastError,
+		CreatedAt:     m.CreatedAt,
+		NextAttemptAt: m.NextAttemptAt,
+		SentAt:        m.SentAt,
+	}
+}
+
+func OutboxMessageFromDomain(d domain.OutboxMessage) (OutboxMessage, error) {
+	objectID, err := primitive.ObjectIDFromHex(d.ID)
+	if err != nil {
+		return OutboxMessage{}, err
+	}
+
+	return OutboxMessage{
+		ID:            objectID,
+		Exchange:      d.Exchange,
+		RoutingKey:    d.RoutingKey,
+		Payload:       d.Payload,
+		Status:        string(d.Status),
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		CreatedAt:     d.CreatedAt,
+		NextAttemptAt: d.NextAttemptAt,
+		SentAt:        d.SentAt,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Reaction struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id"`
+	CommentID primitive.ObjectID `json:"comment_id" bson:"comment_id"`
+	UserID    int64              `json:"user_id" bson:"user_id"`
+	Emoji     string             `json:"emoji" bson:"emoji"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+func ReactionFromDomain(d domain.Reaction) (Reaction, error) {
+	commentID, err := primitive.ObjectIDFromHex(d.CommentID)
+	if err != nil {
+		return Reaction{}, err
+	}
+
+	return Reaction{
+		ID:        primitive.NewObjectID(),
+		CommentID: commentID,
+		UserID:    d.UserID,
+		Emoji:     d.Emoji,
+		CreatedAt: d.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Report struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id"`
+	CommentID  primitive.ObjectID `json:"comment_id" bson:"comment_id"`
+	PostID     primitive.ObjectID `json:"post_id" bson:"post_id"`
+	ClubID     int64              `json:"club_id" bson:"club_id"`
+	ReporterID int64              `json:"reporter_id" bson:"reporter_id"`
+	Reason     string             `json:"reason,omitempty" bson:"reason,omitempty"`
+	Status     string             `json:"status" bson:"status"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	ResolvedAt *time.Time         `json:"resolved_at,omitempty" bson:"resolved_at,omitempty"`
+	ResolvedBy int64              `json:"resolved_by,omitempty" bson:"resolved_by,omitempty"`
+}
+
+func (r *Report) ToDomain() domain.Report {
+	if r == nil {
+		return domain.Report{}
+	}
+
+	return domain.Report{
+		ID:         r.ID.Hex(),
+		CommentID:  r.CommentID.Hex(),
+		PostID:     r.PostID.Hex(),
+		ClubID:     r.ClubID,
+		ReporterID: r.ReporterID,
+		Reason:     r.Reason,
+		Status:     domain.ReportStatus(r.Status),
+		CreatedAt:  r.CreatedAt,
+		ResolvedAt: r.ResolvedAt,
+		ResolvedBy: r.ResolvedBy,
+	}
+}
+
+func ReportFromDomain(d domain.Report) (Report, error) {
+	objectID, err := primitive.ObjectIDFromHex(d.ID)
+	if err != nil {
+		return Report{}, err
+	}
+	commentID, err := primitive.ObjectIDFromHex(d.CommentID)
+	if err != nil {
+		return Report{}, err
+	}
+	postID, err := primitive.ObjectIDFromHex(d.PostID)
+	if err != nil {
+		return Report{}, err
+	}
+
+	return Report{
+		ID:         objectID,
+		CommentID:  commentID,
+		PostID:     postID,
+		ClubID:     d.ClubID,
+		ReporterID: d.ReporterID,
+		Reason:     d.Reason,
+		Status:     string(d.Status),
+		CreatedAt:  d.CreatedAt,
+		ResolvedAt: d.ResolvedAt,
+		ResolvedBy: d.ResolvedBy,
+	}, nil
+}
+
+func ReportsToDomain(reports []Report) []domain.Report {
+	domainReports := make([]domain.Report, 0, len(reports))
+	for _, report := range reports {
+		domainReports = append(domainReports, report.ToDomain())
+	}
+	return domainReports
+}
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Revision struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id"`
+	CommentID primitive.ObjectID `json:"comment_id" bson:"comment_id"`
+	Body      string             `json:"body" bson:"body"`
+	EditorID  int64              `json:"editor_id" bson:"editor_id"`
+	EditedAt  time.Time          `json:"edited_at" bson:"edited_at"`
+}
+
+func (r *Revision) ToDomain() domain.Revision {
+	if r == nil {
+		return domain.Revision{}
+	}
+
+	return domain.Revision{
+		ID:        r.ID.Hex(),
+		CommentID: r.CommentID.Hex(),
+		Body:      r.Body,
+		EditorID:  r.EditorID,
+		EditedAt:  r.EditedAt,
+	}
+}
+
+func RevisionFromDomain(d domain.Revision) (Revision, error) {
+	objectID, err := primitive.ObjectIDFromHex(d.ID)
+	if err != nil {
+		return Revision{}, err
+	}
+	commentID, err := primitive.ObjectIDFromHex(d.CommentID)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	return Revision{
+		ID:        objectID,
+		CommentID: commentID,
+		Body:      d.Body,
+		EditorID:  d.EditorID,
+		EditedAt:  d.EditedAt,
+	}, nil
+}
+
+func RevisionsToDomain(revisions []Revision) []domain.Revision {
+	domainRevisions := make([]domain.Revision, 0, len(revisions))
+	for _, revision := range revisions {
+		domainRevisions = append(domainRevisions, revision.ToDomain())
+	}
+	return domainRevisions
+}
@@ -0,0 +1,121 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/storage/mongodb/dao"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// sentOutboxRetention is how long the sent outbox messages are kept, e.g. to investigate the deliveries
+const sentOutboxRetention = 7 * 24 * time.Hour
+
+// AddOutboxMessages stores the messages as pending, call it in the transaction of the change the messages describe
+func (s *Storage) AddOutboxMessages(ctx context.Context, messages ...domain.OutboxMessage) error {
+	const op = "storage.mongodb.add_outbox_messages"
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	docs := make([]any, 0, len(messages))
+	for _, message := range messages {
+		doc, err := dao.OutboxMessageFromDomain(message)
+		if err != nil {
+			if errors.Is(err, primitive.ErrInvalidHex) {
+				return domain.ErrInvalidID
+			}
+			return fmt.Errorf("%s: failed to convert domain outbox message to dao: %w", op, err)
+		}
+		docs = append(docs, doc)
+	}
+
+	_, err := s.outboxCollection.InsertMany(ctx, docs)
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert documents: %w", op, err)
+	}
+
+	return nil
+}
+
+// ClaimOutboxMessage returns the oldest pending message that is due at now and postpones it by lease,
+// so the other relays skip it while it is published. domain.ErrOutboxEmpty is returned if no message is due.
+func (s *Storage) ClaimOutboxMessage(ctx context.Context, now time.Time, lease time.Duration) (domain.OutboxMessage, error) {
+	const op = "storage.mongodb.claim_outbox_message"
+
+	var message dao.OutboxMessage
+	err := s.outboxCollection.FindOneAndUpdate(
+		ctx,
+		bson.M{"status": domain.OutboxPending, "next_attempt_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&message)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.OutboxMessage{}, domain.ErrOutboxEmpty
+		}
+		return domain.OutboxMessage{}, fmt.Errorf("%s: failed to find and update document: %w", op, err)
+	}
+
+	return message.ToDomain(), nil
+}
+
+// MarkOutboxMessageSent marks the message as sent, sent messages are removed by mongo after the retention period
+func (s *Storage) MarkOutboxMessageSent(ctx context.Context, id string, sentAt time.Time) error {
+	const op = "storage.mongodb.mark_outbox_message_sent"
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.ErrInvalidID
+		}
+		return fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	_, err = s.outboxCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID},
+		bson.M{"$set": bson.M{"status": domain.OutboxSent, "sent_at": sentAt}},
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update document: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkOutboxMessageFailed records the failed attempt, the message is published again at nextAttemptAt
+func (s *Storage) MarkOutboxMessageFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	const op = "storage.mongodb.mark_outbox_message_failed"
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.ErrInvalidID
+		}
+		return fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	_, err = s.outboxCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID, "status": domain.OutboxPending},
+		bson.M{
+			"$set": bson.M{"last_error": lastError, "next_attempt_at": nextAttemptAt},
+			"$inc": bson.M{"attempts": 1},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update document: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/storage/mongodb/dao"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddReaction stores the user's reaction and increments the comment counter.
+// Adding the same reaction twice is a no-op.
+func (s *Storage) AddReaction(ctx context.Context, reaction domain.Reaction) (domain.Comment, error) {
+	const op = "storage.mongodb.add_reaction"
+
+	doc, err := dao.ReactionFromDomain(reaction)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.Comment{}, domain.ErrInvalidID
+		}
+		return domain.Comment{}, fmt.Errorf("%s: failed to convert domain reaction to dao: %w", op, err)
+	}
+
+	_, err = s.reactionCollection.InsertOne(ctx, doc)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return s.GetComment(ctx, reaction.CommentID)
+		}
+		return domain.Comment{}, fmt.Errorf("%s: failed to insert document: %w", op, err)
+	}
+
+	return s.incReactionCount(ctx, op, doc.CommentID, doc.Emoji, 1)
+}
+
+// RemoveReaction deletes the user's reaction and decrements the comment counter.
+// Removing a reaction that does not exist is a no-op.
+func (s *Storage) RemoveReaction(ctx context.Context, reaction domain.Reaction) (domain.Comment, error) {
+	const op = "storage.mongodb.remove_reaction"
+
+	commentID, err := primitive.ObjectIDFromHex(reaction.CommentID)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.Comment{}, domain.ErrInvalidID
+		}
+		return domain.Comment{}, fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	result, err := s.reactionCollection.DeleteOne(ctx, bson.M{
+		"comment_id": commentID,
+		"user_id":    reaction.UserID,
+		"emoji":      reaction.Emoji,
+	})
+	if err != nil {
+		return domain.Comment{}, fmt.Errorf("%s: failed to delete document: %w", op, err)
+	}
+	if result.DeletedCount == 0 {
+		return s.GetComment(ctx, reaction.CommentID)
+	}
+
+	comment, err := s.incReactionCount(ctx, op, commentID, reaction.Emoji, -1)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	// drop the counter once nobody reacts with this emoji anymore
+	if comment.Reactions[reaction.Emoji] <= 0 {
+		key := "reactions." + reaction.Emoji
+		_, err = s.commentCollection.UpdateOne(ctx, bson.M{"_id": commentID, key: bson.M{"$lte": 0}}, bson.M{"$unset": bson.M{key: ""}})
+		if err != nil {
+			return domain.Comment{}, fmt.Errorf("%s: failed to unset reaction counter: %w", op, err)
+		}
+		delete(comment.Reactions, reaction.Emoji)
+	}
+
+	return comment, nil
+}
+
+func (s *Storage) incReactionCount(ctx context.Context, op string, commentID primitive.ObjectID, emoji string, delta int32) (domain.Comment, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var comment dao.Comment
+	err := s.commentCollection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": commentID},
+		bson.M{"$inc": bson.M{"reactions." + emoji: delta}},
+		opts,
+	).Decode(&comment)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Comment{}, domain.ErrCommentNotFound
+		}
+		return domain.Comment{}, fmt.Errorf("%s: failed to update reaction counter: %w", op, err)
+	}
+
+	return comment.ToDomain(), nil
+}
@@ -0,0 +1,155 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/storage/mongodb/dao"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateReport stores the report, domain.ErrAlreadyReported is returned if the reporter has an open report on the comment
+func (s *Storage) CreateReport(ctx context.Context, report domain.Report) error {
+	const op = "storage.mongodb.create_report"
+
+	doc, err := dao.ReportFromDomain(report)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.ErrInvalidID
+		}
+		return fmt.Errorf("%s: failed to convert domain report to dao: %w", op, err)
+	}
+
+	_, err = s.reportCollection.InsertOne(ctx, doc)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrAlreadyReported
+		}
+		return fmt.Errorf("%s: failed to insert document: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetReport(ctx context.Context, id string) (domain.Report, error) {
+	const op = "storage.mongodb.get_report"
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.Report{}, domain.ErrInvalidID
+		}
+		return domain.Report{}, fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	var report dao.Report
+	err = s.reportCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Report{}, domain.ErrReportNotFound
+		}
+		return domain.Report{}, fmt.Errorf("%s: failed to find document: %w", op, err)
+	}
+
+	return report.ToDomain(), nil
+}
+
+// CountOpenReports returns the number of distinct users with an open report on the comment
+func (s *Storage) CountOpenReports(ctx context.Context, commentID string) (int64, error) {
+	const op = "storage.mongodb.count_open_reports"
+
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return 0, domain.ErrInvalidID
+		}
+		return 0, fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	// the unique index keeps a single open report per reporter, so documents are distinct reporters
+	count, err := s.reportCollection.CountDocuments(ctx, bson.M{"comment_id": objectID, "status": domain.ReportOpen})
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to count documents: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// ListOpenReports returns the page of open reports of the club or the post, oldest first
+func (s *Storage) ListOpenReports(ctx context.Context, filter domain.ReportFilter) (
+	[]domain.Report,
+	domain.PaginationMetadata,
+	error,
+) {
+	const op = "storage.mongodb.list_open_reports"
+
+	query := bson.M{"status": domain.ReportOpen}
+	if filter.ClubID != 0 {
+		query["club_id"] = filter.ClubID
+	}
+	if filter.PostID != "" {
+		postID, err := primitive.ObjectIDFromHex(filter.PostID)
+		if err != nil {
+			if errors.Is(err, primitive.ErrInvalidHex) {
+				return nil, domain.PaginationMetadata{}, domain.ErrInvalidID
+			}
+			return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: failed to convert postID to ObjectID: %w", op, err)
+		}
+		query["post_id"] = postID
+	}
+
+	totalRecords, err := s.reportCollection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: failed to count documents: %w", op, err)
+	}
+	if totalRecords == 0 {
+		return []domain.Report{}, domain.PaginationMetadata{}, nil
+	}
+
+	opts := options.Find()
+	opts.SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	opts.SetSkip(int64(filter.Offset()))
+	opts.SetLimit(int64(filter.Limit()))
+
+	cursor, err := s.reportCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: failed to find documents: %w", op, err)
+	}
+
+	var reports []dao.Report
+	err = cursor.All(ctx, &reports)
+	if err != nil {
+		return nil, domain.PaginationMetadata{}, fmt.Errorf("%s: failed to decode documents: %w", op, err)
+	}
+
+	return dao.ReportsToDomain(reports), domain.CalculatePaginationMetadata(int32(totalRecords), filter.Page, filter.PageSize), nil
+}
+
+// ResolveReports closes all the open reports of the comment with the status and returns how many were closed
+func (s *Storage) ResolveReports(ctx context.Context, commentID string, status domain.ReportStatus, resolvedBy int64, resolvedAt time.Time) (int64, error) {
+	const op = "storage.mongodb.resolve_reports"
+
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return 0, domain.ErrInvalidID
+		}
+		return 0, fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	filter := bson.M{"comment_id": objectID, "status": domain.ReportOpen}
+	update := bson.M{"$set": bson.M{"status": status, "resolved_by": resolvedBy, "resolved_at": resolvedAt}}
+
+	result, err := s.reportCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to update documents: %w", op, err)
+	}
+
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/storage/mongodb/dao"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Storage) CreateRevision(ctx context.Context, revision domain.Revision) error {
+	const op = "storage.mongodb.create_revision"
+
+	doc, err := dao.RevisionFromDomain(revision)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return domain.ErrInvalidID
+		}
+		return fmt.Errorf("%s: failed to convert domain revision to dao: %w", op, err)
+	}
+
+	_, err = s.revisionCollection.InsertOne(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert document: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListCommentRevisions returns the previous versions of the comment, oldest first
+func (s *Storage) ListCommentRevisions(ctx context.Context, commentID string) ([]domain.Revision, error) {
+	const op = "storage.mongodb.list_comment_revisions"
+
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return nil, domain.ErrInvalidID
+		}
+		return nil, fmt.Errorf("%s: failed to convert id to ObjectID: %w", op, err)
+	}
+
+	opts := options.Find().SetSort(bson.M{"edited_at": 1})
+
+	cursor, err := s.revisionCollection.Find(ctx, bson.M{"comment_id": objectID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to find documents: %w", op, err)
+	}
+
+	var revisions []dao.Revision
+	err = cursor.All(ctx, &revisions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to decode documents: %w", op, err)
+	}
+
+	return dao.RevisionsToDomain(revisions), nil
+}
@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 type Storage struct {
-	client            *mongo.Client
-	commentCollection *mongo.Collection
+	client             *mongo.Client
+	commentCollection  *mongo.Collection
+	reactionCollection *mongo.Collection
+	revisionCollection *mongo.Collection
+	banCollection      *mongo.Collection
+	reportCollection   *mongo.Collection
+	outboxCollection   *mongo.Collection
 }
 
 // NewStorage creates a new MongoDB storage instance
@@ -31,8 +38,112 @@ func NewStorage(ctx context.Context, cfg config.MongoDB) (Storage, error) {
 
 	db := client.Database(cfg.DatabaseName)
 	commentsCollection := db.Collection("comments")
+	reactionsCollection := db.Collection("comment_reactions")
+	revisionsCollection := db.Collection("comment_revisions")
+	bansCollection := db.Collection("user_bans")
+	reportsCollection := db.Collection("comment_reports")
+	outboxCollection := db.Collection("comment_outbox")
 
-	return Storage{client: client, commentCollection: commentsCollection}, nil
+	// a user can react with the same emoji only once per comment
+	_, err = reactionsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "comment_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "emoji", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return Storage{}, fmt.Errorf("%s: failed to create reactions index: %w", op, err)
+	}
+
+	// backs the keyset pagination of post comments and replies
+	_, err = commentsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+	})
+	if err != nil {
+		return Storage{}, fmt.Errorf("%s: failed to create comments index: %w", op, err)
+	}
+
+	// backs the review queue of the comments held by the moderation pipeline
+	_, err = commentsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "post_id", Value: 1}, {Key: "created_at", Value: 1}},
+		Options: options.Index().SetPartialFilterExpression(bson.M{"moderation.status": domain.ModerationPending}),
+	})
+	if err != nil {
+		return Storage{}, fmt.Errorf("%s: failed to create pending comments index: %w", op, err)
+	}
+
+	// expired bans are removed by mongo, permanent bans have no until field
+	_, err = bansCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "until", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return Storage{}, fmt.Errorf("%s: failed to create bans index: %w", op, err)
+	}
+
+	// a user has at most one open report per comment, resolved reports do not block new ones
+	_, err = reportsCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "comment_id", Value: 1}, {Key: "reporter_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": domain.ReportOpen}),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "club_id", Value: 1}, {Key: "created_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "post_id", Value: 1}, {Key: "created_at", Value: 1}},
+		},
+	})
+	if err != nil {
+		return Storage{}, fmt.Errorf("%s: failed to create reports indexes: %w", op, err)
+	}
+
+	// relays claim the oldest due pending messages, sent messages are removed by mongo after the retention period
+	_, err = outboxCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}, {Key: "created_at", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "sent_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(sentOutboxRetention.Seconds())),
+		},
+	})
+	if err != nil {
+		return Storage{}, fmt.Errorf("%s: failed to create outbox indexes: %w", op, err)
+	}
+
+	return Storage{
+		client:             client,
+		commentCollection:  commentsCollection,
+		reactionCollection: reactionsCollection,
+		revisionCollection: revisionsCollection,
+		banCollection:      bansCollection,
+		reportCollection:   reportsCollection,
+		outboxCollection:   outboxCollection,
+	}, nil
+}
+
+// WithTransaction runs fn in a transaction, the storage calls made with the context passed to fn are part of it.
+// The transaction is committed if fn returns nil, fn may be called again if the transaction has to be retried.
+// Transactions need mongo to run as a replica set.
+func (s *Storage) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	const op = "storage.mongodb.with_transaction"
+
+	session, err := s.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("%s: failed to start session: %w", op, err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (any, error) {
+		return nil, fn(sessionCtx)
+	})
+	if err != nil {
+		// the errors of fn are returned as they are, e.g. domain.ErrCommentNotFound
+		return err
+	}
+
+	return nil
 }
 
 func (s *Storage) Stop(ctx context.Context) error {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/storage/mongodb/dao"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -53,3 +55,51 @@ func (s *Storage) UpdateUser(ctx context.Context, user domain.User) error {
 
 	return nil
 }
+
+// FindPostUsersByName returns the distinct authors of the comments of the post with the full name, the case is ignored
+func (s *Storage) FindPostUsersByName(ctx context.Context, postID string, firstName, lastName string) ([]domain.User, error) {
+	const op = "storage.mongodb.find_post_users_by_name"
+
+	objectID, err := primitive.ObjectIDFromHex(postID)
+	if err != nil {
+		if errors.Is(err, primitive.ErrInvalidHex) {
+			return nil, domain.ErrInvalidID
+		}
+		return nil, fmt.Errorf("%s: failed to convert postID to ObjectID: %w", op, err)
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{
+			"post_id":         objectID,
+			"deleted_at":      nil,
+			"user.first_name": exactNameRegex(firstName),
+			"user.last_name":  exactNameRegex(lastName),
+		}},
+		bson.M{"$group": bson.M{"_id": "$user._id", "user": bson.M{"$first": "$user"}}},
+	}
+
+	cursor, err := s.commentCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to aggregate documents: %w", op, err)
+	}
+
+	var authors []struct {
+		User dao.User `bson:"user"`
+	}
+	err = cursor.All(ctx, &authors)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to decode documents: %w", op, err)
+	}
+
+	users := make([]domain.User, 0, len(authors))
+	for _, author := range authors {
+		users = append(users, author.User.ToDomain())
+	}
+
+	return users, nil
+}
+
+// exactNameRegex matches the whole name ignoring the case
+func exactNameRegex(name string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(name) + "$", Options: "i"}
+}
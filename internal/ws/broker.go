@@ -0,0 +1,61 @@
+package ws
+
+import (
+	"fmt"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"github.com/centrifugal/centrifuge"
+)
+
+const (
+	BrokerMemory = "memory"
+	BrokerRedis  = "redis"
+)
+
+// setupBroker replaces the in-memory broker and presence manager of the node when the config asks for another backend,
+// it must be called before the node runs
+func setupBroker(node *centrifuge.Node, cfg config.Broker) error {
+	switch cfg.Type {
+	case "", BrokerMemory:
+		return nil
+	case BrokerRedis:
+		return setupRedisBroker(node, cfg.Redis)
+	default:
+		return fmt.Errorf("unknown broker type %q", cfg.Type)
+	}
+}
+
+// setupRedisBroker shares publications, history and presence between the nodes connected to the same Redis
+func setupRedisBroker(node *centrifuge.Node, cfg config.Redis) error {
+	shard, err := centrifuge.NewRedisShard(node, centrifuge.RedisShardConfig{
+		Address:          cfg.Address,
+		ClusterAddresses: cfg.ClusterAddresses,
+		User:             cfg.User,
+		Password:         cfg.Password,
+		DB:               cfg.DB,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating redis shard: %w", err)
+	}
+
+	broker, err := centrifuge.NewRedisBroker(node, centrifuge.RedisBrokerConfig{
+		Prefix: cfg.Prefix,
+		Shards: []*centrifuge.RedisShard{shard},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating redis broker: %w", err)
+	}
+
+	presenceManager, err := centrifuge.NewRedisPresenceManager(node, centrifuge.RedisPresenceManagerConfig{
+		Prefix: cfg.Prefix,
+		Shards: []*centrifuge.RedisShard{shard},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating redis presence manager: %w", err)
+	}
+
+	node.SetBroker(broker)
+	node.SetPresenceManager(presenceManager)
+
+	return nil
+}
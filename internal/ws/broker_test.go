@@ -0,0 +1,89 @@
+package ws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ws/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/centrifugal/centrifuge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedisManager(t *testing.T, redis *miniredis.Miniredis) *Manager {
+	m, err := NewManager(logger.Plug(), config.Broker{
+		Type: BrokerRedis,
+		Redis: config.Redis{
+			// miniredis answers the cluster commands, so the redis client always runs in cluster mode with it
+			ClusterAddresses: []string{redis.Addr()},
+			Prefix:           "test",
+		},
+	}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = m.Stop(context.Background())
+	})
+	return m
+}
+
+func TestNewManager_RedisBroker(t *testing.T) {
+	redis := miniredis.RunT(t)
+	first := newRedisManager(t, redis)
+	second := newRedisManager(t, redis)
+
+	t.Run("history is shared between instances", func(t *testing.T) {
+		data, err := json.Marshal(Event{Type: EventNewComment, Payload: json.RawMessage(`{}`)})
+		require.NoError(t, err)
+
+		published, err := first.node.Publish("post", data, centrifuge.WithHistory(300, time.Minute))
+		require.NoError(t, err)
+
+		history, err := second.node.History("post", centrifuge.WithLimit(centrifuge.NoLimit))
+		require.NoError(t, err)
+		assert.Equal(t, published.StreamPosition, history.StreamPosition)
+		if assert.Len(t, history.Publications, 1) {
+			assert.JSONEq(t, string(data), string(history.Publications[0].Data))
+		}
+	})
+
+	t.Run("post publications reach the subscribers of other instances", func(t *testing.T) {
+		postID := domain.NewID()
+		client, transport := connectTestClient(t, second, 1)
+		require.NoError(t, client.Subscribe(PostChannel(postID), centrifuge.WithEmitPresence(true)))
+
+		presence, err := first.node.Presence(PostChannel(postID))
+		require.NoError(t, err)
+		if assert.Contains(t, presence.Presence, client.ID(), "presence is shared between instances") {
+			assert.Equal(t, "1", presence.Presence[client.ID()].UserID)
+		}
+
+		comment := domain.Comment{ID: domain.NewID(), PostID: postID, Body: "hello"}
+		err = first.HandleCommentEvent(context.Background(), domain.NewCommentEvent(domain.CommentCreated, comment, 2))
+		require.NoError(t, err)
+
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case frame := <-transport.written:
+				if bytes.Contains(frame, []byte(comment.ID)) {
+					assert.Contains(t, string(frame), string(EventNewComment))
+					return
+				}
+			case <-timeout:
+				t.Fatal("publication was not delivered to the subscriber of the other instance")
+			}
+		}
+	})
+}
+
+func TestNewManager_UnknownBroker(t *testing.T) {
+	_, err := NewManager(logger.Plug(), config.Broker{Type: "kafka"}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/centrifugal/centrifuge"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Channel namespaces, a channel name is the namespace followed by the id, e.g. post:<post_id>
+const (
+	NamespacePost = "post"
+	NamespaceClub = "club"
+)
+
+var ErrInvalidChannel = errors.New("invalid channel")
+
+// AccessChecker tells whether the user may subscribe to the channels of posts and clubs
+//
+//go:generate mockery --name AccessChecker
+type AccessChecker interface {
+	CanViewPost(ctx context.Context, userID int64, postID string) (bool, error)
+	CanViewClub(ctx context.Context, userID int64, clubID int64) (bool, error)
+}
+
+// PostChannel returns the channel where the comments of the post are published
+func PostChannel(postID string) string {
+	return NamespacePost + ":" + postID
+}
+
+// ClubChannel returns the channel of the club
+func ClubChannel(clubID int64) string {
+	return NamespaceClub + ":" + strconv.FormatInt(clubID, 10)
+}
+
+// PersonalChannel returns the channel of the user, every connection of the user is subscribed to it by the server
+func PersonalChannel(userID string) string {
+	return "#" + userID
+}
+
+// parseChannel splits the channel into its namespace and id and validates the id
+func parseChannel(channel string) (namespace, id string, err error) {
+	namespace, id, found := strings.Cut(channel, ":")
+	if !found || id == "" {
+		return "", "", ErrInvalidChannel
+	}
+
+	switch namespace {
+	case NamespacePost:
+		if !primitive.IsValidObjectID(id) {
+			return "", "", ErrInvalidChannel
+		}
+	case NamespaceClub:
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+			return "", "", ErrInvalidChannel
+		}
+	default:
+		return "", "", ErrInvalidChannel
+	}
+
+	return namespace, id, nil
+}
+
+// authorizeSubscription checks that the channel is known and the user may see it,
+// it returns the centrifuge error to reject the subscription with
+func (m *Manager) authorizeSubscription(ctx context.Context, userID int64, channel string) error {
+	namespace, id, err := parseChannel(channel)
+	if err != nil {
+		return centrifuge.ErrorUnknownChannel
+	}
+
+	var allowed bool
+	switch namespace {
+	case NamespacePost:
+		allowed, err = m.accessChecker.CanViewPost(ctx, userID, id)
+	case NamespaceClub:
+		clubID, _ := strconv.ParseInt(id, 10, 64)
+		allowed, err = m.accessChecker.CanViewClub(ctx, userID, clubID)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPostNotFound),
+			errors.Is(err, domain.ErrClubNotFound),
+			errors.Is(err, domain.ErrInvalidArg):
+			return centrifuge.ErrorUnknownChannel
+		default:
+			m.log.Error("error checking channel access", slog.String("channel", channel), logger.Err(err))
+			return centrifuge.ErrorInternal
+		}
+	}
+	if !allowed {
+		return centrifuge.ErrorPermissionDenied
+	}
+
+	return nil
+}
+
+// authorizePostView returns domain.ErrUnauthorized unless the user may view the post,
+// the rpc reads are guarded by the same check as the subscriptions to the post channel
+func (m *Manager) authorizePostView(ctx context.Context, userID int64, postID string) error {
+	allowed, err := m.accessChecker.CanViewPost(ctx, userID, postID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return domain.ErrUnauthorized
+	}
+	return nil
+}
+
+// authorizeChannelComment returns the comment when it belongs to the post of the channel and the user may view the post,
+// the events on a comment are accepted only in the channel of its post
+func (m *Manager) authorizeChannelComment(ctx context.Context, userID int64, channel, commentID string) (domain.Comment, error) {
+	comment, err := m.commentService.GetByID(ctx, commentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	if channel != PostChannel(comment.PostID) {
+		return domain.Comment{}, fmt.Errorf("%w: comment_id does not belong to the post of the channel", domain.ErrInvalidArg)
+	}
+
+	err = m.authorizePostView(ctx, userID, comment.PostID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	return comment, nil
+}
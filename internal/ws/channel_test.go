@@ -0,0 +1,121 @@
+package ws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ws/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/centrifugal/centrifuge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseChannel(t *testing.T) {
+	postID := domain.NewID()
+
+	tests := []struct {
+		name          string
+		channel       string
+		wantNamespace string
+		wantID        string
+		wantErr       bool
+	}{
+		{name: "post channel", channel: PostChannel(postID), wantNamespace: NamespacePost, wantID: postID},
+		{name: "club channel", channel: ClubChannel(42), wantNamespace: NamespaceClub, wantID: "42"},
+		{name: "no namespace", channel: postID, wantErr: true},
+		{name: "unknown namespace", channel: "user:1", wantErr: true},
+		{name: "empty id", channel: "post:", wantErr: true},
+		{name: "invalid post id", channel: "post:123", wantErr: true},
+		{name: "invalid club id", channel: "club:abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, id, err := parseChannel(tt.channel)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidChannel)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNamespace, namespace)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func TestManager_authorizeSubscription(t *testing.T) {
+	postID := domain.NewID()
+
+	tests := []struct {
+		name        string
+		channel     string
+		setup       func(checker *mocks.AccessChecker)
+		expectedErr error
+	}{
+		{
+			name:    "post visible",
+			channel: PostChannel(postID),
+			setup: func(checker *mocks.AccessChecker) {
+				checker.On("CanViewPost", mock.Anything, int64(1), postID).Return(true, nil)
+			},
+		},
+		{
+			name:    "club member",
+			channel: ClubChannel(7),
+			setup: func(checker *mocks.AccessChecker) {
+				checker.On("CanViewClub", mock.Anything, int64(1), int64(7)).Return(true, nil)
+			},
+		},
+		{
+			name:        "unknown channel",
+			channel:     "comments",
+			setup:       func(checker *mocks.AccessChecker) {},
+			expectedErr: centrifuge.ErrorUnknownChannel,
+		},
+		{
+			name:    "post not found",
+			channel: PostChannel(postID),
+			setup: func(checker *mocks.AccessChecker) {
+				checker.On("CanViewPost", mock.Anything, int64(1), postID).Return(false, domain.ErrPostNotFound)
+			},
+			expectedErr: centrifuge.ErrorUnknownChannel,
+		},
+		{
+			name:    "post not visible",
+			channel: PostChannel(postID),
+			setup: func(checker *mocks.AccessChecker) {
+				checker.On("CanViewPost", mock.Anything, int64(1), postID).Return(false, nil)
+			},
+			expectedErr: centrifuge.ErrorPermissionDenied,
+		},
+		{
+			name:    "not a club member",
+			channel: ClubChannel(7),
+			setup: func(checker *mocks.AccessChecker) {
+				checker.On("CanViewClub", mock.Anything, int64(1), int64(7)).Return(false, nil)
+			},
+			expectedErr: centrifuge.ErrorPermissionDenied,
+		},
+		{
+			name:    "unexpected error",
+			channel: PostChannel(postID),
+			setup: func(checker *mocks.AccessChecker) {
+				checker.On("CanViewPost", mock.Anything, int64(1), postID).Return(false, assert.AnError)
+			},
+			expectedErr: centrifuge.ErrorInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := mocks.NewAccessChecker(t)
+			tt.setup(checker)
+			m := &Manager{log: logger.Plug(), accessChecker: checker}
+
+			err := m.authorizeSubscription(context.Background(), 1, tt.channel)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
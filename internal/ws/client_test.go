@@ -0,0 +1,92 @@
+package ws
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	pkgjwt "github.com/ARUMANDESU/uniclubs-comments-service/pkg/jwt"
+	"github.com/centrifugal/centrifuge"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const testJWTSecret = "test-secret"
+
+// testTransport is an in-process unidirectional transport, it keeps the latest frames written to the client
+// and drops the rest once the buffer is full
+type testTransport struct {
+	closed  chan centrifuge.Disconnect
+	written chan []byte
+}
+
+func newTestTransport() *testTransport {
+	return &testTransport{closed: make(chan centrifuge.Disconnect, 1), written: make(chan []byte, 16)}
+}
+
+func (t *testTransport) Name() string                      { return "test" }
+func (t *testTransport) Protocol() centrifuge.ProtocolType { return centrifuge.ProtocolTypeJSON }
+func (t *testTransport) ProtocolVersion() centrifuge.ProtocolVersion {
+	return centrifuge.ProtocolVersion2
+}
+func (t *testTransport) Unidirectional() bool      { return true }
+func (t *testTransport) Emulation() bool           { return false }
+func (t *testTransport) DisabledPushFlags() uint64 { return 0 }
+func (t *testTransport) PingPongConfig() centrifuge.PingPongConfig {
+	return centrifuge.PingPongConfig{PingInterval: -1, PongTimeout: -1}
+}
+func (t *testTransport) Write(data []byte) error { return t.WriteMany(data) }
+func (t *testTransport) WriteMany(data ...[]byte) error {
+	for _, frame := range data {
+		select {
+		case t.written <- frame:
+		default:
+		}
+	}
+	return nil
+}
+func (t *testTransport) Close(d centrifuge.Disconnect) error {
+	select {
+	case t.closed <- d:
+	default:
+	}
+	return nil
+}
+
+// revokedUsers is the revocation checker of the tests, the users in the set are banned
+type revokedUsers map[int64]bool
+
+func (r revokedUsers) IsRevoked(_ context.Context, userID int64) bool {
+	return r[userID]
+}
+
+// testTokenVerifier returns the verifier of the tokens made by newTestToken
+func testTokenVerifier() TokenVerifier {
+	return pkgjwt.NewHMACVerifier(testJWTSecret)
+}
+
+// newTestToken returns a token the manager accepts for the user
+func newTestToken(t *testing.T, userID int64, expiresAt time.Time) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID,
+		"exp":     expiresAt.Unix(),
+	}).SignedString([]byte(testJWTSecret))
+	require.NoError(t, err)
+	return token
+}
+
+// connectTestClient connects an in-process client of the user to the manager node
+func connectTestClient(t *testing.T, m *Manager, userID int64) (*centrifuge.Client, *testTransport) {
+	transport := newTestTransport()
+	client, closeFn, err := centrifuge.NewClient(context.Background(), m.node, transport)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = closeFn()
+	})
+
+	client.Connect(centrifuge.ConnectRequest{Token: newTestToken(t, userID, time.Now().Add(time.Hour))})
+	require.Equal(t, strconv.FormatInt(userID, 10), client.UserID())
+
+	return client, transport
+}
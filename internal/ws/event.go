@@ -12,23 +12,54 @@ type clientMessage struct {
 	PublishEvent centrifuge.PublishEvent
 }
 
+// clientRequest is a struct that contains the rpc call and the client that sent it
+type clientRequest struct {
+	Client   *centrifuge.Client
+	RPCEvent centrifuge.RPCEvent
+}
+
 // EventHandler is a function signature that is used to affect messages on the socket and triggered depending on the type
 type EventHandler func(message clientMessage) (centrifuge.PublishReply, error)
 
+// RequestHandler is a function signature that is used to answer rpc calls, the rpc method is the event type
+type RequestHandler func(request clientRequest) (centrifuge.RPCReply, error)
+
 type EventType string
 
 // Client events which receive from the client
 const (
-	EventCreateComment EventType = "create_comment"
-	EventUpdateComment EventType = "update_comment"
-	EventDeleteComment EventType = "delete_comment"
+	EventCreateComment  EventType = "create_comment"
+	EventUpdateComment  EventType = "update_comment"
+	EventDeleteComment  EventType = "delete_comment"
+	EventRestoreComment EventType = "restore_comment"
+	EventAddReaction    EventType = "add_reaction"
+	EventRemoveReaction EventType = "remove_reaction"
+	EventHideComment    EventType = "hide_comment"
+	EventUnhideComment  EventType = "unhide_comment"
+	EventReportComment  EventType = "report_comment"
+	EventApproveComment EventType = "approve_comment"
+	EventRejectComment  EventType = "reject_comment"
+)
+
+// Client requests which are sent by the client as rpc calls and answered only to the caller
+const (
+	EventListComments  EventType = "list_comments"
+	EventListReplies   EventType = "list_replies"
+	EventListRevisions EventType = "list_revisions"
+	EventSyncComments  EventType = "sync_comments"
+	EventListReports   EventType = "list_reports"
+	EventResolveReport EventType = "resolve_report"
+	EventListPending   EventType = "list_pending"
 )
 
 // Server events which are sent to the client
 const (
-	EventNewComment    EventType = "new_comment"
-	EventEditComment   EventType = "edit_comment"
-	EventRemoveComment EventType = "remove_comment"
+	EventNewComment      EventType = "new_comment"
+	EventEditComment     EventType = "edit_comment"
+	EventRemoveComment   EventType = "remove_comment"
+	EventReactionUpdated EventType = "reaction_updated"
+	// EventMentioned is sent to the personal channel of the users mentioned in a comment
+	EventMentioned EventType = "mentioned"
 )
 
 // Event is the Messages sent over the websocket
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
 	"github.com/centrifugal/centrifuge"
 )
@@ -14,12 +15,18 @@ import (
 // handleCreateComment is an event handler that is triggered when a client sends a create_comment event
 func (m *Manager) handleCreateComment(message clientMessage) (centrifuge.PublishReply, error) {
 	var input struct {
-		Body   string `json:"body"`
-		PostID string `json:"post_id"`
+		Body     string `json:"body"`
+		PostID   string `json:"post_id"`
+		ParentID string `json:"parent_id"`
 	}
 	err := json.Unmarshal(message.Event.Payload, &input)
 	if err != nil {
-		return centrifuge.PublishReply{}, err
+		return centrifuge.PublishReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+
+	// comments are published only to the channel of their post
+	if message.PublishEvent.Channel != PostChannel(input.PostID) {
+		return centrifuge.PublishReply{}, fmt.Errorf("%w: post_id does not match the channel", domain.ErrInvalidArg)
 	}
 
 	userID, err := strconv.ParseInt(message.PublishEvent.ClientInfo.UserID, 10, 64)
@@ -30,49 +37,81 @@ func (m *Manager) handleCreateComment(message clientMessage) (centrifuge.Publish
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	createdComment, err := m.commentService.Create(ctx, commentservice.CreateCommentDTO{
-		Body:   input.Body,
-		PostID: input.PostID,
-		UserID: userID,
+	_, err = m.commentService.Create(ctx, commentservice.CreateCommentDTO{
+		Body:     input.Body,
+		PostID:   input.PostID,
+		ParentID: input.ParentID,
+		UserID:   userID,
 	})
 	if err != nil {
 		return centrifuge.PublishReply{}, err
 	}
 
-	// Marshal the created comment into a json.RawMessage
-	payload, err := json.Marshal(createdComment)
+	return acceptedReply(), nil
+}
+
+func (m *Manager) handleDeleteComment(message clientMessage) (centrifuge.PublishReply, error) {
+	var input struct {
+		CommentID string `json:"comment_id"`
+	}
+	err := json.Unmarshal(message.Event.Payload, &input)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+
+	userID, err := strconv.ParseInt(message.PublishEvent.ClientInfo.UserID, 10, 64)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+	}
+
+	err = m.commentService.Delete(context.TODO(), commentservice.DeleteCommentDTO{
+		CommentID: input.CommentID,
+		UserID:    userID,
+	})
 	if err != nil {
 		return centrifuge.PublishReply{}, err
 	}
 
-	// Create a new event with the created comment as the payload
-	event := Event{
-		Type:      EventNewComment,
-		Payload:   payload,
-		Timestamp: time.Now().Unix(),
+	return acceptedReply(), nil
+}
+
+func (m *Manager) handleUpdateComment(message clientMessage) (centrifuge.PublishReply, error) {
+	var input struct {
+		CommentID string `json:"comment_id"`
+		Body      string `json:"body"`
+	}
+	err := json.Unmarshal(message.Event.Payload, &input)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
 	}
 
-	data, _ := json.Marshal(event)
+	userID, err := strconv.ParseInt(message.PublishEvent.ClientInfo.UserID, 10, 64)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+	}
 
-	result, err := m.node.Publish(
-		message.PublishEvent.Channel, data,
-		centrifuge.WithHistory(300, time.Minute),
-		centrifuge.WithClientInfo(message.PublishEvent.ClientInfo),
-	)
+	_, err = m.commentService.Update(context.TODO(), commentservice.UpdateCommentDTO{
+		CommentID: input.CommentID,
+		Body:      input.Body,
+		UserID:    userID,
+	})
 	if err != nil {
-		return centrifuge.PublishReply{}, fmt.Errorf("error publishing message: %w", err)
+		return centrifuge.PublishReply{}, err
 	}
 
-	return centrifuge.PublishReply{Result: &result}, nil
+	return acceptedReply(), nil
 }
 
-func (m *Manager) handleDeleteComment(message clientMessage) (centrifuge.PublishReply, error) {
+// handleRestoreComment is an event handler that is triggered when a client sends a restore_comment event
+//
+// The restored comment is broadcast as an edit_comment event, clients replace the tombstone or show the comment again
+func (m *Manager) handleRestoreComment(message clientMessage) (centrifuge.PublishReply, error) {
 	var input struct {
 		CommentID string `json:"comment_id"`
 	}
 	err := json.Unmarshal(message.Event.Payload, &input)
 	if err != nil {
-		return centrifuge.PublishReply{}, err
+		return centrifuge.PublishReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
 	}
 
 	userID, err := strconv.ParseInt(message.PublishEvent.ClientInfo.UserID, 10, 64)
@@ -80,7 +119,10 @@ func (m *Manager) handleDeleteComment(message clientMessage) (centrifuge.Publish
 		return centrifuge.PublishReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
 	}
 
-	err = m.commentService.Delete(context.TODO(), commentservice.DeleteCommentDTO{
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = m.commentService.Restore(ctx, commentservice.RestoreCommentDTO{
 		CommentID: input.CommentID,
 		UserID:    userID,
 	})
@@ -88,71 +130,161 @@ func (m *Manager) handleDeleteComment(message clientMessage) (centrifuge.Publish
 		return centrifuge.PublishReply{}, err
 	}
 
-	event := Event{
-		Type:      EventRemoveComment,
-		Payload:   message.Event.Payload,
-		Timestamp: time.Now().Unix(),
-	}
+	return acceptedReply(), nil
+}
 
-	data, _ := json.Marshal(event)
+// handleListComments is a request handler that is triggered when a client calls the list_comments rpc
+//
+// It lists the top-level comments of the post, newest first. Pass the cursor from the metadata to get the next
+// or previous page, it keeps the pages stable while new comments arrive.
+func (m *Manager) handleListComments(request clientRequest) (centrifuge.RPCReply, error) {
+	var input struct {
+		PostID   string `json:"post_id"`
+		Page     int32  `json:"page"`
+		PageSize int32  `json:"page_size"`
+		Cursor   string `json:"cursor"`
+	}
+	err := json.Unmarshal(request.RPCEvent.Data, &input)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
 
-	result, err := m.node.Publish(
-		message.PublishEvent.Channel, data,
-		centrifuge.WithHistory(300, time.Minute),
-		centrifuge.WithClientInfo(message.PublishEvent.ClientInfo),
+	filter, err := domain.NewFilter(
+		domain.WithPage(input.Page),
+		domain.WithPageSize(input.PageSize),
+		domain.WithCursor(input.Cursor),
 	)
 	if err != nil {
-		return centrifuge.PublishReply{}, fmt.Errorf("error publishing message: %w", err)
+		return centrifuge.RPCReply{}, err
+	}
+
+	userID, err := strconv.ParseInt(request.Client.UserID(), 10, 64)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
 	}
 
-	return centrifuge.PublishReply{Result: &result}, nil
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = m.authorizePostView(ctx, userID, input.PostID)
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	comments, metadata, err := m.commentService.ListByPostID(ctx, input.PostID, *filter)
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	data, err := json.Marshal(struct {
+		Comments []domain.Comment          `json:"comments"`
+		Metadata domain.PaginationMetadata `json:"metadata"`
+	}{
+		Comments: comments,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	return centrifuge.RPCReply{Data: data}, nil
 }
 
-func (m *Manager) handleUpdateComment(message clientMessage) (centrifuge.PublishReply, error) {
+// handleListReplies is a request handler that is triggered when a client calls the list_replies rpc
+func (m *Manager) handleListReplies(request clientRequest) (centrifuge.RPCReply, error) {
 	var input struct {
 		CommentID string `json:"comment_id"`
-		Body      string `json:"body"`
+		Page      int32  `json:"page"`
+		PageSize  int32  `json:"page_size"`
+		Cursor    string `json:"cursor"`
 	}
-	err := json.Unmarshal(message.Event.Payload, &input)
+	err := json.Unmarshal(request.RPCEvent.Data, &input)
 	if err != nil {
-		return centrifuge.PublishReply{}, err
+		return centrifuge.RPCReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
 	}
 
-	userID, err := strconv.ParseInt(message.PublishEvent.ClientInfo.UserID, 10, 64)
+	filter, err := domain.NewFilter(
+		domain.WithPage(input.Page),
+		domain.WithPageSize(input.PageSize),
+		domain.WithSortOrder(domain.SortOrderAsc),
+		domain.WithCursor(input.Cursor),
+	)
 	if err != nil {
-		return centrifuge.PublishReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+		return centrifuge.RPCReply{}, err
 	}
 
-	updatedComment, err := m.commentService.Update(context.TODO(), commentservice.UpdateCommentDTO{
-		CommentID: input.CommentID,
-		Body:      input.Body,
-		UserID:    userID,
+	userID, err := strconv.ParseInt(request.Client.UserID(), 10, 64)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the replies are readable by the users who can view the post of the parent comment
+	parent, err := m.commentService.GetByID(ctx, input.CommentID)
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	err = m.authorizePostView(ctx, userID, parent.PostID)
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	replies, metadata, err := m.commentService.ListReplies(ctx, input.CommentID, *filter)
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	data, err := json.Marshal(struct {
+		Replies  []domain.Comment          `json:"replies"`
+		Metadata domain.PaginationMetadata `json:"metadata"`
+	}{
+		Replies:  replies,
+		Metadata: metadata,
 	})
 	if err != nil {
-		return centrifuge.PublishReply{}, err
+		return centrifuge.RPCReply{}, err
 	}
 
-	payload, err := json.Marshal(updatedComment)
+	return centrifuge.RPCReply{Data: data}, nil
+}
+
+// handleListRevisions is a request handler that is triggered when a client calls the list_revisions rpc
+func (m *Manager) handleListRevisions(request clientRequest) (centrifuge.RPCReply, error) {
+	var input struct {
+		CommentID string `json:"comment_id"`
+	}
+	err := json.Unmarshal(request.RPCEvent.Data, &input)
 	if err != nil {
-		return centrifuge.PublishReply{}, err
+		return centrifuge.RPCReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
 	}
 
-	event := Event{
-		Type:      EventEditComment,
-		Payload:   payload,
-		Timestamp: time.Now().Unix(),
+	userID, err := strconv.ParseInt(request.Client.UserID(), 10, 64)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
 	}
 
-	data, _ := json.Marshal(event)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	result, err := m.node.Publish(
-		message.PublishEvent.Channel, data,
-		centrifuge.WithHistory(300, time.Minute),
-		centrifuge.WithClientInfo(message.PublishEvent.ClientInfo),
-	)
+	revisions, err := m.commentService.ListRevisions(ctx, commentservice.ListRevisionsDTO{
+		CommentID: input.CommentID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	data, err := json.Marshal(struct {
+		Revisions []domain.Revision `json:"revisions"`
+	}{
+		Revisions: revisions,
+	})
 	if err != nil {
-		return centrifuge.PublishReply{}, fmt.Errorf("error publishing message: %w", err)
+		return centrifuge.RPCReply{}, err
 	}
 
-	return centrifuge.PublishReply{Result: &result}, nil
+	return centrifuge.RPCReply{Data: data}, nil
 }
@@ -0,0 +1,130 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ws/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/centrifugal/centrifuge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newReaderRequest returns the rpc call of the connected user with the id 3
+func newReaderRequest(t *testing.T, commentService CommentService, accessChecker AccessChecker, method EventType, data string) (*Manager, clientRequest) {
+	m, err := NewManager(logger.Plug(), config.Broker{}, commentService, accessChecker, testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = m.Stop(context.Background()) })
+
+	client, _ := connectTestClient(t, m, 3)
+	return m, clientRequest{Client: client, RPCEvent: centrifuge.RPCEvent{Method: string(method), Data: []byte(data)}}
+}
+
+func TestManager_handleListComments(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	accessChecker := mocks.NewAccessChecker(t)
+	m, request := newReaderRequest(t, commentService, accessChecker, EventListComments, `{"post_id":"p1"}`)
+
+	accessChecker.On("CanViewPost", mock.Anything, int64(3), "p1").Return(true, nil)
+	commentService.On("ListByPostID", mock.Anything, "p1", mock.Anything).
+		Return([]domain.Comment{{ID: "c1", PostID: "p1"}}, domain.PaginationMetadata{TotalRecords: 1}, nil)
+
+	reply, err := m.handleListComments(request)
+	require.NoError(t, err)
+
+	var result struct {
+		Comments []domain.Comment `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(reply.Data, &result))
+	assert.Len(t, result.Comments, 1)
+}
+
+func TestManager_handleListComments_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowed       bool
+		onCanView     error
+		expectedError error
+	}{
+		{
+			name:          "post not visible",
+			expectedError: domain.ErrUnauthorized,
+		},
+		{
+			name:          "post not found",
+			onCanView:     domain.ErrPostNotFound,
+			expectedError: domain.ErrPostNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessChecker := mocks.NewAccessChecker(t)
+			m, request := newReaderRequest(t, mocks.NewCommentService(t), accessChecker, EventListComments, `{"post_id":"p1"}`)
+			accessChecker.On("CanViewPost", mock.Anything, int64(3), "p1").Return(tt.allowed, tt.onCanView)
+
+			_, err := m.handleListComments(request)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestManager_handleListReplies(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	accessChecker := mocks.NewAccessChecker(t)
+	m, request := newReaderRequest(t, commentService, accessChecker, EventListReplies, `{"comment_id":"c1"}`)
+
+	commentService.On("GetByID", mock.Anything, "c1").Return(domain.Comment{ID: "c1", PostID: "p1"}, nil)
+	accessChecker.On("CanViewPost", mock.Anything, int64(3), "p1").Return(true, nil)
+	commentService.On("ListReplies", mock.Anything, "c1", mock.Anything).
+		Return([]domain.Comment{{ID: "c2", PostID: "p1", ParentID: "c1"}}, domain.PaginationMetadata{TotalRecords: 1}, nil)
+
+	reply, err := m.handleListReplies(request)
+	require.NoError(t, err)
+
+	var result struct {
+		Replies []domain.Comment `json:"replies"`
+	}
+	require.NoError(t, json.Unmarshal(reply.Data, &result))
+	assert.Len(t, result.Replies, 1)
+}
+
+func TestManager_handleListReplies_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		onGetByID     error
+		allowed       bool
+		expectedError error
+	}{
+		{
+			name:          "parent not found",
+			onGetByID:     domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "post not visible",
+			expectedError: domain.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentService := mocks.NewCommentService(t)
+			accessChecker := mocks.NewAccessChecker(t)
+			m, request := newReaderRequest(t, commentService, accessChecker, EventListReplies, `{"comment_id":"c1"}`)
+
+			commentService.On("GetByID", mock.Anything, "c1").Return(domain.Comment{ID: "c1", PostID: "p1"}, tt.onGetByID)
+			if tt.onGetByID == nil {
+				accessChecker.On("CanViewPost", mock.Anything, int64(3), "p1").Return(tt.allowed, nil)
+			}
+
+			_, err := m.handleListReplies(request)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
@@ -7,10 +7,10 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"os"
 	"strconv"
 	"time"
 
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
 	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/jwt"
@@ -23,11 +23,25 @@ var (
 )
 
 type Manager struct {
-	log      *slog.Logger
-	node     *centrifuge.Node
-	handlers map[EventType]EventHandler
+	log             *slog.Logger
+	node            *centrifuge.Node
+	handlers        map[EventType]EventHandler
+	requestHandlers map[EventType]RequestHandler
 
 	commentService CommentService
+	accessChecker  AccessChecker
+	tokenVerifier  TokenVerifier
+	revocations    RevocationChecker
+}
+
+// TokenVerifier verifies the connection token of the client
+type TokenVerifier interface {
+	Verify(tokenString string) (jwt.Claims, error)
+}
+
+// RevocationChecker reports whether the access of the user is revoked
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, userID int64) bool
 }
 
 //go:generate mockery --name CommentService
@@ -35,20 +49,52 @@ type CommentService interface {
 	Create(ctx context.Context, comment commentservice.CreateCommentDTO) (domain.Comment, error)
 	Update(ctx context.Context, dto commentservice.UpdateCommentDTO) (domain.Comment, error)
 	Delete(ctx context.Context, dto commentservice.DeleteCommentDTO) error
+	Restore(ctx context.Context, dto commentservice.RestoreCommentDTO) (domain.Comment, error)
+	GetByID(ctx context.Context, id string) (domain.Comment, error)
+	ListByPostID(ctx context.Context, postID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error)
+	Sync(ctx context.Context, postID string, after domain.SyncCursor) (commentservice.SyncResult, error)
+	ListReplies(ctx context.Context, commentID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error)
+	ListRevisions(ctx context.Context, dto commentservice.ListRevisionsDTO) ([]domain.Revision, error)
+	AddReaction(ctx context.Context, dto commentservice.ReactionDTO) (domain.Comment, error)
+	RemoveReaction(ctx context.Context, dto commentservice.ReactionDTO) (domain.Comment, error)
+	Hide(ctx context.Context, dto commentservice.HideCommentDTO) (domain.Comment, error)
+	Unhide(ctx context.Context, dto commentservice.HideCommentDTO) (domain.Comment, error)
+	Report(ctx context.Context, dto commentservice.ReportCommentDTO) (domain.Report, error)
+	ListReports(ctx context.Context, dto commentservice.ListReportsDTO) ([]domain.Report, domain.PaginationMetadata, error)
+	ResolveReport(ctx context.Context, dto commentservice.ResolveReportDTO) (domain.Report, error)
+	ListPending(ctx context.Context, dto commentservice.ListPendingDTO) ([]domain.Comment, domain.PaginationMetadata, error)
+	Approve(ctx context.Context, dto commentservice.ReviewCommentDTO) (domain.Comment, error)
+	Reject(ctx context.Context, dto commentservice.ReviewCommentDTO) error
 }
 
-func NewManager(log *slog.Logger, commentService CommentService) (*Manager, error) {
+func NewManager(
+	log *slog.Logger,
+	brokerConfig config.Broker,
+	commentService CommentService,
+	accessChecker AccessChecker,
+	tokenVerifier TokenVerifier,
+	revocations RevocationChecker,
+) (*Manager, error) {
 	node, err := centrifuge.New(centrifuge.Config{})
 	if err != nil {
 		return nil, err
 	}
 
+	err = setupBroker(node, brokerConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	m := &Manager{
-		log:      log,
-		node:     node,
-		handlers: make(map[EventType]EventHandler),
+		log:             log,
+		node:            node,
+		handlers:        make(map[EventType]EventHandler),
+		requestHandlers: make(map[EventType]RequestHandler),
 
 		commentService: commentService,
+		accessChecker:  accessChecker,
+		tokenVerifier:  tokenVerifier,
+		revocations:    revocations,
 	}
 
 	m.setupEventHandlers()
@@ -61,51 +107,201 @@ func NewManager(log *slog.Logger, commentService CommentService) (*Manager, erro
 	return m, nil
 }
 
+// connectReply accepts the connection of the user and subscribes it to its personal channel
+//
+// The client refreshes the connection itself by sending a fresh token before the current one expires,
+// a connection that is not refreshed in time is closed with DisconnectExpired.
+func connectReply(credentials *centrifuge.Credentials) centrifuge.ConnectReply {
+	return centrifuge.ConnectReply{
+		Credentials:       credentials,
+		ClientSideRefresh: true,
+		Subscriptions: map[string]centrifuge.SubscribeOptions{
+			PersonalChannel(credentials.UserID): {
+				EnableRecovery: true,
+				EmitPresence:   true,
+				EmitJoinLeave:  true,
+				PushJoinLeave:  true,
+			},
+		},
+	}
+}
+
+// handleRefresh extends the connection until the expiration of the fresh token sent by the client.
+// The token must belong to the connected user, an expired token or a refresh without a token closes the connection
+// with DisconnectExpired, a banned user is closed with DisconnectPermissionDenied.
+func (m *Manager) handleRefresh(client *centrifuge.Client, e centrifuge.RefreshEvent) (centrifuge.RefreshReply, error) {
+	const op = "ws.manager.refresh"
+	log := m.log.With(slog.String("op", op), slog.String("user_id", client.UserID()))
+
+	if !e.ClientSideRefresh || e.Token == "" {
+		return centrifuge.RefreshReply{Expired: true}, nil
+	}
+
+	claims, err := m.tokenVerifier.Verify(e.Token)
+	if err != nil {
+		if errors.Is(err, domain.ErrTokenIsExpired) {
+			return centrifuge.RefreshReply{Expired: true}, nil
+		}
+		if errors.Is(err, jwt.ErrKeyUnavailable) {
+			log.Error("failed to verify refresh token", logger.Err(err))
+			return centrifuge.RefreshReply{}, centrifuge.ErrorInternal
+		}
+		log.Info("invalid refresh token", logger.Err(err))
+		return centrifuge.RefreshReply{}, centrifuge.DisconnectInvalidToken
+	}
+
+	if strconv.FormatInt(claims.UserID, 10) != client.UserID() {
+		log.Warn("refresh token belongs to another user", slog.Int64("token_user_id", claims.UserID))
+		return centrifuge.RefreshReply{}, centrifuge.DisconnectInvalidToken
+	}
+
+	if m.revocations.IsRevoked(client.Context(), claims.UserID) {
+		log.Info("refresh of banned user")
+		return centrifuge.RefreshReply{}, centrifuge.DisconnectPermissionDenied
+	}
+
+	return centrifuge.RefreshReply{ExpireAt: claims.ExpiresAt.Unix()}, nil
+}
+
+// DisconnectUser closes the connections of the user on all the nodes, the clients do not reconnect
+func (m *Manager) DisconnectUser(userID int64) error {
+	const op = "ws.manager.disconnect_user"
+
+	err := m.node.Disconnect(
+		strconv.FormatInt(userID, 10),
+		centrifuge.WithCustomDisconnect(centrifuge.DisconnectPermissionDenied),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ErrorCodeCommentRejected is the code of the error replied to a comment rejected by the moderation,
+// the message holds the reason
+const ErrorCodeCommentRejected uint32 = 422
+
+func commentRejectedError(err error) *centrifuge.Error {
+	message := domain.ErrCommentRejected.Error()
+	var rejected domain.CommentRejectedError
+	if errors.As(err, &rejected) {
+		message = rejected.Error()
+	}
+	return &centrifuge.Error{Code: ErrorCodeCommentRejected, Message: message}
+}
+
+// clientError returns the error replied to the client for the error of a publish or rpc handler,
+// the errors of unknown comments, posts and reports are bad requests like the invalid arguments
+func clientError(err error) *centrifuge.Error {
+	switch {
+	case errors.Is(err, ErrEventNotSupported):
+		return centrifuge.ErrorMethodNotFound
+	case errors.Is(err, domain.ErrInvalidID),
+		errors.Is(err, domain.ErrInvalidArg),
+		errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrCommentNotFound),
+		errors.Is(err, domain.ErrPostNotFound),
+		errors.Is(err, domain.ErrClubNotFound),
+		errors.Is(err, domain.ErrParentPostMismatch),
+		errors.Is(err, domain.ErrParentUnavailable),
+		errors.Is(err, domain.ErrInvalidReaction),
+		errors.Is(err, domain.ErrCommentNotDeleted),
+		errors.Is(err, domain.ErrCommentNotPending),
+		errors.Is(err, domain.ErrReportNotFound),
+		errors.Is(err, domain.ErrReportResolved),
+		errors.Is(err, domain.ErrInvalidReportAction),
+		errors.Is(err, domain.ErrAlreadyReported),
+		errors.Is(err, domain.ErrOwnCommentReport):
+		return centrifuge.ErrorBadRequest
+	case errors.Is(err, domain.ErrUnauthorized),
+		errors.Is(err, domain.ErrUserBanned):
+		return centrifuge.ErrorPermissionDenied
+	case errors.Is(err, domain.ErrTooManyRequests):
+		return centrifuge.ErrorTooManyRequests
+	case errors.Is(err, domain.ErrCommentRejected):
+		return commentRejectedError(err)
+	default:
+		return centrifuge.ErrorInternal
+	}
+}
+
+// tokenError returns the centrifuge error for the token verification error
+func (m *Manager) tokenError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrTokenIsExpired):
+		return centrifuge.ErrorTokenExpired
+	case errors.Is(err, domain.ErrTokenIsNotValid),
+		errors.Is(err, domain.ErrInvalidTokenClaims),
+		errors.Is(err, domain.ErrUserIDClaimNotFound),
+		errors.Is(err, domain.ErrUnexpectedSigningMethod),
+		errors.Is(err, domain.ErrTokenNotValidYet),
+		errors.Is(err, domain.ErrTokenInvalidIssuer),
+		errors.Is(err, domain.ErrTokenInvalidAudience):
+		return centrifuge.ErrorUnauthorized
+	case errors.Is(err, jwt.ErrKeyUnavailable):
+		m.log.Error("failed to verify connection token", logger.Err(err))
+		return centrifuge.ErrorInternal
+	default:
+		m.log.Warn("unexpected token verification error", logger.Err(err))
+		return centrifuge.DisconnectInvalidToken
+	}
+}
+
 // setupNode configures Centrifuge Node to handle all necessary events.
 func (m *Manager) setupNode() error {
 	m.node.OnConnecting(func(ctx context.Context, e centrifuge.ConnectEvent) (centrifuge.ConnectReply, error) {
+		// the HTTP auth middleware sets the credentials when the upgrade request carries the token
+		credentials, ok := centrifuge.GetCredentials(ctx)
+		if ok {
+			userID, err := strconv.ParseInt(credentials.UserID, 10, 64)
+			if err != nil {
+				return centrifuge.ConnectReply{}, centrifuge.ErrorInternal
+			}
+			if m.revocations.IsRevoked(ctx, userID) {
+				return centrifuge.ConnectReply{}, centrifuge.DisconnectPermissionDenied
+			}
+			return connectReply(credentials), nil
+		}
 
-		userID, expiresAt, err := jwt.GetUserID(e.Token, os.Getenv("JWT_SECRET"))
+		claims, err := m.tokenVerifier.Verify(e.Token)
 		if err != nil {
-			switch {
-			case errors.Is(err, domain.ErrTokenIsNotValid),
-				errors.Is(err, domain.ErrInvalidTokenClaims),
-				errors.Is(err, domain.ErrUserIDClaimNotFound),
-				errors.Is(err, domain.ErrUnexpectedSigningMethod):
-				return centrifuge.ConnectReply{}, centrifuge.ErrorUnauthorized
-			case errors.Is(err, domain.ErrTokenIsExpired):
-				return centrifuge.ConnectReply{}, centrifuge.ErrorTokenExpired
-			default:
-				return centrifuge.ConnectReply{}, centrifuge.DisconnectInvalidToken
-			}
+			return centrifuge.ConnectReply{}, m.tokenError(err)
 		}
 
-		return centrifuge.ConnectReply{
-			Credentials: &centrifuge.Credentials{
-				UserID:   strconv.FormatInt(userID, 10),
-				ExpireAt: expiresAt,
-			},
-			Subscriptions: map[string]centrifuge.SubscribeOptions{
-				"#" + strconv.FormatInt(userID, 10): {
-					EnableRecovery: true,
-					EmitPresence:   true,
-					EmitJoinLeave:  true,
-					PushJoinLeave:  true,
-				},
-			},
-		}, nil
+		if m.revocations.IsRevoked(ctx, claims.UserID) {
+			return centrifuge.ConnectReply{}, centrifuge.DisconnectPermissionDenied
+		}
+
+		return connectReply(&centrifuge.Credentials{
+			UserID:   strconv.FormatInt(claims.UserID, 10),
+			ExpireAt: claims.ExpiresAt.Unix(),
+		}), nil
 	})
 
 	m.node.OnConnect(func(client *centrifuge.Client) {
 		client.OnRefresh(func(e centrifuge.RefreshEvent, cb centrifuge.RefreshCallback) {
-			cb(centrifuge.RefreshReply{
-				ExpireAt: time.Now().Unix() + 60,
-			}, nil)
+			cb(m.handleRefresh(client, e))
 		})
 
 		client.OnSubscribe(func(e centrifuge.SubscribeEvent, cb centrifuge.SubscribeCallback) {
 			m.log.Debug("subscribe event", slog.String("channel", e.Channel), slog.String("user_id", client.UserID()))
 
+			userID, err := strconv.ParseInt(client.UserID(), 10, 64)
+			if err != nil {
+				cb(centrifuge.SubscribeReply{}, centrifuge.ErrorInternal)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(client.Context(), 5*time.Second)
+			defer cancel()
+
+			err = m.authorizeSubscription(ctx, userID, e.Channel)
+			if err != nil {
+				cb(centrifuge.SubscribeReply{}, err)
+				return
+			}
+
 			cb(centrifuge.SubscribeReply{
 				Options: centrifuge.SubscribeOptions{
 					EnableRecovery: true,
@@ -143,18 +339,32 @@ func (m *Manager) setupNode() error {
 				PublishEvent: e,
 			})
 			if err != nil {
-				switch {
-				case errors.Is(err, ErrEventNotSupported):
-					cb(centrifuge.PublishReply{}, centrifuge.ErrorMethodNotFound)
-				default:
-					cb(centrifuge.PublishReply{}, centrifuge.ErrorInternal)
-				}
+				cb(centrifuge.PublishReply{}, clientError(err))
 				return
 			}
 
 			cb(publishReply, err)
 		})
 
+		client.OnRPC(func(e centrifuge.RPCEvent, cb centrifuge.RPCCallback) {
+			m.log.Debug(
+				"rpc event",
+				slog.String("method", e.Method),
+				slog.String("user_id", client.UserID()),
+			)
+
+			reply, err := m.routeRequest(clientRequest{
+				Client:   client,
+				RPCEvent: e,
+			})
+			if err != nil {
+				cb(centrifuge.RPCReply{}, clientError(err))
+				return
+			}
+
+			cb(reply, nil)
+		})
+
 		client.OnPresence(func(e centrifuge.PresenceEvent, cb centrifuge.PresenceCallback) {
 			if !client.IsSubscribed(e.Channel) {
 				cb(centrifuge.PresenceReply{}, centrifuge.ErrorPermissionDenied)
@@ -188,6 +398,22 @@ func (m *Manager) setupEventHandlers() {
 	m.handlers[EventCreateComment] = m.handleCreateComment
 	m.handlers[EventUpdateComment] = m.handleUpdateComment
 	m.handlers[EventDeleteComment] = m.handleDeleteComment
+	m.handlers[EventRestoreComment] = m.handleRestoreComment
+	m.handlers[EventAddReaction] = m.handleAddReaction
+	m.handlers[EventRemoveReaction] = m.handleRemoveReaction
+	m.handlers[EventHideComment] = m.handleHideComment
+	m.handlers[EventUnhideComment] = m.handleUnhideComment
+	m.handlers[EventReportComment] = m.handleReportComment
+	m.handlers[EventApproveComment] = m.handleApproveComment
+	m.handlers[EventRejectComment] = m.handleRejectComment
+
+	m.requestHandlers[EventListComments] = m.handleListComments
+	m.requestHandlers[EventListReplies] = m.handleListReplies
+	m.requestHandlers[EventListRevisions] = m.handleListRevisions
+	m.requestHandlers[EventSyncComments] = m.handleSyncComments
+	m.requestHandlers[EventListReports] = m.handleListReports
+	m.requestHandlers[EventResolveReport] = m.handleResolveReport
+	m.requestHandlers[EventListPending] = m.handleListPending
 }
 
 // routeEvent routes the event to the correct handler
@@ -209,16 +435,49 @@ func (m *Manager) routeEvent(msg clientMessage) (centrifuge.PublishReply, error)
 	}
 }
 
-// WebsocketHandler returns a http.Handler that can be used to upgrade HTTP
-func (m *Manager) WebsocketHandler() http.Handler {
+// routeRequest routes the rpc call to the correct request handler, the rpc method is used as the event type
+//
+// It will return the reply from the handler
+// If the event is not supported, it will return an error
+func (m *Manager) routeRequest(req clientRequest) (centrifuge.RPCReply, error) {
+	log := m.log.With(slog.String("event", req.RPCEvent.Method))
+
+	handler, ok := m.requestHandlers[EventType(req.RPCEvent.Method)]
+	if !ok {
+		return centrifuge.RPCReply{}, ErrEventNotSupported
+	}
+
+	reply, err := handler(req)
+	if err != nil {
+		log.Error("error handling request", logger.Err(err))
+		return centrifuge.RPCReply{}, fmt.Errorf("error handling request: %w", err)
+	}
+
+	return reply, nil
+}
+
+// WebsocketHandler returns a http.Handler that can be used to upgrade HTTP,
+// checkOrigin decides which browser origins may open the connection
+func (m *Manager) WebsocketHandler(checkOrigin func(r *http.Request) bool) http.Handler {
 	return centrifuge.NewWebsocketHandler(m.node, centrifuge.WebsocketConfig{
-		CheckOrigin: func(r *http.Request) bool {
-			originHeader := r.Header.Get("Origin")
-			if originHeader == "" || originHeader == "null" {
-				return true
-			}
-			return originHeader == "http://localhost:3000"
-		}})
+		CheckOrigin: checkOrigin,
+	})
+}
+
+// HTTPStreamHandler returns the handler of the HTTP-streaming transport for the clients that can not use websockets
+func (m *Manager) HTTPStreamHandler() http.Handler {
+	return centrifuge.NewHTTPStreamHandler(m.node, centrifuge.HTTPStreamConfig{})
+}
+
+// SSEHandler returns the handler of the Server-Sent Events transport for the clients that can not use websockets
+func (m *Manager) SSEHandler() http.Handler {
+	return centrifuge.NewSSEHandler(m.node, centrifuge.SSEConfig{})
+}
+
+// EmulationHandler returns the handler receiving the client commands of the unidirectional HTTP-streaming and
+// SSE connections, the commands are passed to the node of the connection over the broker
+func (m *Manager) EmulationHandler() http.Handler {
+	return centrifuge.NewEmulationHandler(m.node, centrifuge.EmulationConfig{})
 }
 
 func (m *Manager) Stop(ctx context.Context) error {
@@ -1,12 +1,25 @@
 package ws
 
 import (
+	"bufio"
+	"context"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ws/mocks"
 	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
 	"github.com/centrifugal/centrifuge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestManager_routeEvent(t *testing.T) {
@@ -84,3 +97,265 @@ func TestManager_routeEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_routeRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		requestHandlers map[EventType]RequestHandler
+		req             clientRequest
+		want            centrifuge.RPCReply
+		wantErr         error
+	}{
+		{
+			name:            "unsupported request",
+			requestHandlers: nil,
+			req:             clientRequest{RPCEvent: centrifuge.RPCEvent{Method: "unsupported"}},
+			want:            centrifuge.RPCReply{},
+			wantErr:         ErrEventNotSupported,
+		},
+		{
+			name: "list replies",
+			requestHandlers: map[EventType]RequestHandler{"list_replies": func(req clientRequest) (centrifuge.RPCReply, error) {
+				return centrifuge.RPCReply{Data: []byte(`{"replies":[]}`)}, nil
+			}},
+			req:     clientRequest{RPCEvent: centrifuge.RPCEvent{Method: "list_replies"}},
+			want:    centrifuge.RPCReply{Data: []byte(`{"replies":[]}`)},
+			wantErr: nil,
+		},
+		{
+			name: "handler error",
+			requestHandlers: map[EventType]RequestHandler{"list_replies": func(req clientRequest) (centrifuge.RPCReply, error) {
+				return centrifuge.RPCReply{}, domain.ErrCommentNotFound
+			}},
+			req:     clientRequest{RPCEvent: centrifuge.RPCEvent{Method: "list_replies"}},
+			want:    centrifuge.RPCReply{},
+			wantErr: domain.ErrCommentNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manager{
+				log:             logger.Plug(),
+				requestHandlers: tt.requestHandlers,
+			}
+			got, err := m.routeRequest(tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_OnConnecting_ContextCredentials(t *testing.T) {
+	m, err := NewManager(logger.Plug(), config.Broker{}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	defer m.Stop(context.Background())
+
+	ctx := centrifuge.SetCredentials(context.Background(), &centrifuge.Credentials{
+		UserID:   "42",
+		ExpireAt: time.Now().Add(time.Hour).Unix(),
+	})
+	client, closeFn, err := centrifuge.NewClient(ctx, m.node, newTestTransport())
+	require.NoError(t, err)
+	defer closeFn()
+
+	client.Connect(centrifuge.ConnectRequest{})
+
+	assert.Equal(t, "42", client.UserID())
+	assert.True(t, client.IsSubscribed("#42"))
+}
+
+func TestManager_OnConnecting_RevokedUser(t *testing.T) {
+	m, err := NewManager(logger.Plug(), config.Broker{}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{1: true})
+	require.NoError(t, err)
+	defer m.Stop(context.Background())
+
+	transport := newTestTransport()
+	client, closeFn, err := centrifuge.NewClient(context.Background(), m.node, transport)
+	require.NoError(t, err)
+	defer closeFn()
+
+	client.Connect(centrifuge.ConnectRequest{Token: newTestToken(t, 1, time.Now().Add(time.Hour))})
+
+	select {
+	case d := <-transport.closed:
+		assert.Equal(t, centrifuge.DisconnectPermissionDenied.Code, d.Code)
+	case <-time.After(time.Second):
+		t.Fatal("banned user is not disconnected")
+	}
+	assert.Empty(t, client.UserID())
+}
+
+func TestManager_DisconnectUser(t *testing.T) {
+	m, err := NewManager(logger.Plug(), config.Broker{}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	defer m.Stop(context.Background())
+
+	_, banned := connectTestClient(t, m, 1)
+	_, other := connectTestClient(t, m, 2)
+
+	err = m.DisconnectUser(1)
+	require.NoError(t, err)
+
+	select {
+	case d := <-banned.closed:
+		assert.Equal(t, centrifuge.DisconnectPermissionDenied.Code, d.Code)
+	case <-time.After(time.Second):
+		t.Fatal("user is not disconnected")
+	}
+	assert.Empty(t, other.closed)
+}
+
+func TestManager_handleRefresh(t *testing.T) {
+	m, err := NewManager(logger.Plug(), config.Broker{}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	defer m.Stop(context.Background())
+
+	client, _ := connectTestClient(t, m, 1)
+	expiresAt := time.Now().Add(2 * time.Hour)
+
+	reply, err := m.handleRefresh(client, centrifuge.RefreshEvent{
+		ClientSideRefresh: true,
+		Token:             newTestToken(t, 1, expiresAt),
+	})
+
+	require.NoError(t, err)
+	assert.False(t, reply.Expired)
+	assert.Equal(t, expiresAt.Unix(), reply.ExpireAt)
+}
+
+func TestManager_handleRefresh_FailPath(t *testing.T) {
+	tests := []struct {
+		name            string
+		event           func(t *testing.T) centrifuge.RefreshEvent
+		banned          bool
+		expectedExpired bool
+		expectedError   error
+	}{
+		{
+			name: "server side refresh",
+			event: func(t *testing.T) centrifuge.RefreshEvent {
+				return centrifuge.RefreshEvent{}
+			},
+			expectedExpired: true,
+		},
+		{
+			name: "expired token",
+			event: func(t *testing.T) centrifuge.RefreshEvent {
+				return centrifuge.RefreshEvent{ClientSideRefresh: true, Token: newTestToken(t, 1, time.Now().Add(-time.Hour))}
+			},
+			expectedExpired: true,
+		},
+		{
+			name: "invalid token",
+			event: func(t *testing.T) centrifuge.RefreshEvent {
+				return centrifuge.RefreshEvent{ClientSideRefresh: true, Token: "abc"}
+			},
+			expectedError: centrifuge.DisconnectInvalidToken,
+		},
+		{
+			name: "token of another user",
+			event: func(t *testing.T) centrifuge.RefreshEvent {
+				return centrifuge.RefreshEvent{ClientSideRefresh: true, Token: newTestToken(t, 2, time.Now().Add(time.Hour))}
+			},
+			expectedError: centrifuge.DisconnectInvalidToken,
+		},
+		{
+			name: "banned user",
+			event: func(t *testing.T) centrifuge.RefreshEvent {
+				return centrifuge.RefreshEvent{ClientSideRefresh: true, Token: newTestToken(t, 1, time.Now().Add(time.Hour))}
+			},
+			banned:        true,
+			expectedError: centrifuge.DisconnectPermissionDenied,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			revoked := revokedUsers{}
+			m, err := NewManager(logger.Plug(), config.Broker{}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revoked)
+			require.NoError(t, err)
+			defer m.Stop(context.Background())
+
+			client, _ := connectTestClient(t, m, 1)
+			revoked[1] = tc.banned
+
+			reply, err := m.handleRefresh(client, tc.event(t))
+
+			assert.Equal(t, tc.expectedExpired, reply.Expired)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestManager_SSEHandler(t *testing.T) {
+	m, err := NewManager(logger.Plug(), config.Broker{}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	defer m.Stop(context.Background())
+
+	server := httptest.NewServer(m.SSEHandler())
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	connect := `{"id":1,"connect":{"token":"` + newTestToken(t, 1, time.Now().Add(time.Hour)) + `"}}`
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?cf_connect="+url.QueryEscape(connect), nil)
+	require.NoError(t, err)
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	// the stream starts with an empty line before the first event
+	reader := bufio.NewReader(response.Body)
+	var line string
+	for !strings.HasPrefix(line, "data:") {
+		line, err = reader.ReadString('\n')
+		require.NoError(t, err)
+	}
+	assert.Contains(t, line, `"connect":{"client"`)
+	assert.Contains(t, line, `"#1"`)
+}
+
+func TestCommentRejectedError(t *testing.T) {
+	err := fmt.Errorf("error handling event: %w", domain.CommentRejectedError{Reason: "body is empty"})
+
+	assert.Equal(t, &centrifuge.Error{
+		Code:    ErrorCodeCommentRejected,
+		Message: "comment rejected by moderation: body is empty",
+	}, commentRejectedError(err))
+}
+
+func TestClientError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want *centrifuge.Error
+	}{
+		{err: ErrEventNotSupported, want: centrifuge.ErrorMethodNotFound},
+		{err: domain.ErrInvalidArg, want: centrifuge.ErrorBadRequest},
+		{err: domain.ErrInvalidID, want: centrifuge.ErrorBadRequest},
+		{err: domain.ErrCommentNotFound, want: centrifuge.ErrorBadRequest},
+		{err: domain.ErrParentPostMismatch, want: centrifuge.ErrorBadRequest},
+		{err: domain.ErrInvalidReaction, want: centrifuge.ErrorBadRequest},
+		{err: domain.ErrCommentNotDeleted, want: centrifuge.ErrorBadRequest},
+		{err: domain.ErrReportNotFound, want: centrifuge.ErrorBadRequest},
+		{err: domain.ErrAlreadyReported, want: centrifuge.ErrorBadRequest},
+		{err: domain.ErrUnauthorized, want: centrifuge.ErrorPermissionDenied},
+		{err: domain.ErrUserBanned, want: centrifuge.ErrorPermissionDenied},
+		{err: domain.ErrTooManyRequests, want: centrifuge.ErrorTooManyRequests},
+		{err: domain.ErrInternal, want: centrifuge.ErrorInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			// the handler errors reach the callbacks wrapped by routeEvent and routeRequest
+			assert.Equal(t, tt.want, clientError(fmt.Errorf("error handling event: %w", tt.err)))
+		})
+	}
+}
@@ -17,6 +17,62 @@ type CommentService struct {
 	mock.Mock
 }
 
+// AddReaction provides a mock function with given fields: ctx, dto
+func (_m *CommentService) AddReaction(ctx context.Context, dto commentservice.ReactionDTO) (domain.Comment, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReaction")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ReactionDTO) (domain.Comment, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ReactionDTO) domain.Comment); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.ReactionDTO) error); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Approve provides a mock function with given fields: ctx, dto
+func (_m *CommentService) Approve(ctx context.Context, dto commentservice.ReviewCommentDTO) (domain.Comment, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Approve")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ReviewCommentDTO) (domain.Comment, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ReviewCommentDTO) domain.Comment); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.ReviewCommentDTO) error); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, comment
 func (_m *CommentService) Create(ctx context.Context, comment commentservice.CreateCommentDTO) (domain.Comment, error) {
 	ret := _m.Called(ctx, comment)
@@ -63,6 +119,426 @@ func (_m *CommentService) Delete(ctx context.Context, dto commentservice.DeleteC
 	return r0
 }
 
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *CommentService) GetByID(ctx context.Context, id string) (domain.Comment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Comment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Comment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Hide provides a mock function with given fields: ctx, dto
+func (_m *CommentService) Hide(ctx context.Context, dto commentservice.HideCommentDTO) (domain.Comment, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Hide")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.HideCommentDTO) (domain.Comment, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.HideCommentDTO) domain.Comment); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.HideCommentDTO) error); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByPostID provides a mock function with given fields: ctx, postID, filter
+func (_m *CommentService) ListByPostID(ctx context.Context, postID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error) {
+	ret := _m.Called(ctx, postID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByPostID")
+	}
+
+	var r0 []domain.Comment
+	var r1 domain.PaginationMetadata
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error)); ok {
+		return rf(ctx, postID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Filter) []domain.Comment); ok {
+		r0 = rf(ctx, postID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.Filter) domain.PaginationMetadata); ok {
+		r1 = rf(ctx, postID, filter)
+	} else {
+		r1 = ret.Get(1).(domain.PaginationMetadata)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, domain.Filter) error); ok {
+		r2 = rf(ctx, postID, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListPending provides a mock function with given fields: ctx, dto
+func (_m *CommentService) ListPending(ctx context.Context, dto commentservice.ListPendingDTO) ([]domain.Comment, domain.PaginationMetadata, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPending")
+	}
+
+	var r0 []domain.Comment
+	var r1 domain.PaginationMetadata
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ListPendingDTO) ([]domain.Comment, domain.PaginationMetadata, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ListPendingDTO) []domain.Comment); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.ListPendingDTO) domain.PaginationMetadata); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Get(1).(domain.PaginationMetadata)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, commentservice.ListPendingDTO) error); ok {
+		r2 = rf(ctx, dto)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListReplies provides a mock function with given fields: ctx, commentID, filter
+func (_m *CommentService) ListReplies(ctx context.Context, commentID string, filter domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error) {
+	ret := _m.Called(ctx, commentID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReplies")
+	}
+
+	var r0 []domain.Comment
+	var r1 domain.PaginationMetadata
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Filter) ([]domain.Comment, domain.PaginationMetadata, error)); ok {
+		return rf(ctx, commentID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Filter) []domain.Comment); ok {
+		r0 = rf(ctx, commentID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.Filter) domain.PaginationMetadata); ok {
+		r1 = rf(ctx, commentID, filter)
+	} else {
+		r1 = ret.Get(1).(domain.PaginationMetadata)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, domain.Filter) error); ok {
+		r2 = rf(ctx, commentID, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListReports provides a mock function with given fields: ctx, dto
+func (_m *CommentService) ListReports(ctx context.Context, dto commentservice.ListReportsDTO) ([]domain.Report, domain.PaginationMetadata, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReports")
+	}
+
+	var r0 []domain.Report
+	var r1 domain.PaginationMetadata
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ListReportsDTO) ([]domain.Report, domain.PaginationMetadata, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ListReportsDTO) []domain.Report); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Report)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.ListReportsDTO) domain.PaginationMetadata); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Get(1).(domain.PaginationMetadata)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, commentservice.ListReportsDTO) error); ok {
+		r2 = rf(ctx, dto)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListRevisions provides a mock function with given fields: ctx, dto
+func (_m *CommentService) ListRevisions(ctx context.Context, dto commentservice.ListRevisionsDTO) ([]domain.Revision, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRevisions")
+	}
+
+	var r0 []domain.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ListRevisionsDTO) ([]domain.Revision, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ListRevisionsDTO) []domain.Revision); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.ListRevisionsDTO) error); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reject provides a mock function with given fields: ctx, dto
+func (_m *CommentService) Reject(ctx context.Context, dto commentservice.ReviewCommentDTO) error {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ReviewCommentDTO) error); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveReaction provides a mock function with given fields: ctx, dto
+func (_m *CommentService) RemoveReaction(ctx context.Context, dto commentservice.ReactionDTO) (domain.Comment, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReaction")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ReactionDTO) (domain.Comment, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ReactionDTO) domain.Comment); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.ReactionDTO) error); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Report provides a mock function with given fields: ctx, dto
+func (_m *CommentService) Report(ctx context.Context, dto commentservice.ReportCommentDTO) (domain.Report, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Report")
+	}
+
+	var r0 domain.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ReportCommentDTO) (domain.Report, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ReportCommentDTO) domain.Report); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		r0 = ret.Get(0).(domain.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.ReportCommentDTO) error); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveReport provides a mock function with given fields: ctx, dto
+func (_m *CommentService) ResolveReport(ctx context.Context, dto commentservice.ResolveReportDTO) (domain.Report, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveReport")
+	}
+
+	var r0 domain.Report
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ResolveReportDTO) (domain.Report, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.ResolveReportDTO) domain.Report); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		r0 = ret.Get(0).(domain.Report)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.ResolveReportDTO) error); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, dto
+func (_m *CommentService) Restore(ctx context.Context, dto commentservice.RestoreCommentDTO) (domain.Comment, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.RestoreCommentDTO) (domain.Comment, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.RestoreCommentDTO) domain.Comment); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.RestoreCommentDTO) error); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Sync provides a mock function with given fields: ctx, postID, after
+func (_m *CommentService) Sync(ctx context.Context, postID string, after domain.SyncCursor) (commentservice.SyncResult, error) {
+	ret := _m.Called(ctx, postID, after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sync")
+	}
+
+	var r0 commentservice.SyncResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SyncCursor) (commentservice.SyncResult, error)); ok {
+		return rf(ctx, postID, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SyncCursor) commentservice.SyncResult); ok {
+		r0 = rf(ctx, postID, after)
+	} else {
+		r0 = ret.Get(0).(commentservice.SyncResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.SyncCursor) error); ok {
+		r1 = rf(ctx, postID, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unhide provides a mock function with given fields: ctx, dto
+func (_m *CommentService) Unhide(ctx context.Context, dto commentservice.HideCommentDTO) (domain.Comment, error) {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unhide")
+	}
+
+	var r0 domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.HideCommentDTO) (domain.Comment, error)); ok {
+		return rf(ctx, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, commentservice.HideCommentDTO) domain.Comment); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		r0 = ret.Get(0).(domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, commentservice.HideCommentDTO) error); ok {
+		r1 = rf(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, dto
 func (_m *CommentService) Update(ctx context.Context, dto commentservice.UpdateCommentDTO) (domain.Comment, error) {
 	ret := _m.Called(ctx, dto)
@@ -0,0 +1,147 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+	"github.com/centrifugal/centrifuge"
+)
+
+// handleHideComment is an event handler that is triggered when a moderator sends a hide_comment event
+func (m *Manager) handleHideComment(message clientMessage) (centrifuge.PublishReply, error) {
+	return m.handleModeration(message, m.commentService.Hide)
+}
+
+// handleUnhideComment is an event handler that is triggered when a moderator sends an unhide_comment event
+func (m *Manager) handleUnhideComment(message clientMessage) (centrifuge.PublishReply, error) {
+	return m.handleModeration(message, m.commentService.Unhide)
+}
+
+// handleModeration applies the moderation action, the service emits the edit_comment event
+func (m *Manager) handleModeration(
+	message clientMessage,
+	apply func(ctx context.Context, dto commentservice.HideCommentDTO) (domain.Comment, error),
+) (centrifuge.PublishReply, error) {
+	var input struct {
+		CommentID string `json:"comment_id"`
+	}
+	err := json.Unmarshal(message.Event.Payload, &input)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+
+	userID, err := strconv.ParseInt(message.PublishEvent.ClientInfo.UserID, 10, 64)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = apply(ctx, commentservice.HideCommentDTO{
+		CommentID: input.CommentID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return centrifuge.PublishReply{}, err
+	}
+
+	return acceptedReply(), nil
+}
+
+// handleApproveComment is an event handler that is triggered when a moderator sends an approve_comment event,
+// the service emits the edit_comment event with the body of the approved comment
+func (m *Manager) handleApproveComment(message clientMessage) (centrifuge.PublishReply, error) {
+	return m.handleReview(message, func(ctx context.Context, dto commentservice.ReviewCommentDTO) error {
+		_, err := m.commentService.Approve(ctx, dto)
+		return err
+	})
+}
+
+// handleRejectComment is an event handler that is triggered when a moderator sends a reject_comment event,
+// the service emits the remove_comment event
+func (m *Manager) handleRejectComment(message clientMessage) (centrifuge.PublishReply, error) {
+	return m.handleReview(message, m.commentService.Reject)
+}
+
+// handleReview applies the decision of the moderator on the comment held for review
+func (m *Manager) handleReview(
+	message clientMessage,
+	apply func(ctx context.Context, dto commentservice.ReviewCommentDTO) error,
+) (centrifuge.PublishReply, error) {
+	var input struct {
+		CommentID string `json:"comment_id"`
+	}
+	err := json.Unmarshal(message.Event.Payload, &input)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+
+	userID, err := strconv.ParseInt(message.PublishEvent.ClientInfo.UserID, 10, 64)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = apply(ctx, commentservice.ReviewCommentDTO{
+		CommentID: input.CommentID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return centrifuge.PublishReply{}, err
+	}
+
+	return acceptedReply(), nil
+}
+
+// handleListPending is a request handler that is triggered when a client calls the list_pending rpc,
+// it returns the comments of the post held for review with their original bodies, only moderators can list them
+func (m *Manager) handleListPending(request clientRequest) (centrifuge.RPCReply, error) {
+	var input struct {
+		PostID   string `json:"post_id"`
+		Page     int32  `json:"page"`
+		PageSize int32  `json:"page_size"`
+	}
+	err := json.Unmarshal(request.RPCEvent.Data, &input)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+
+	userID, err := strconv.ParseInt(request.Client.UserID(), 10, 64)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	comments, metadata, err := m.commentService.ListPending(ctx, commentservice.ListPendingDTO{
+		UserID:   userID,
+		PostID:   input.PostID,
+		Page:     input.Page,
+		PageSize: input.PageSize,
+	})
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	data, err := json.Marshal(struct {
+		Comments []domain.Comment          `json:"comments"`
+		Metadata domain.PaginationMetadata `json:"metadata"`
+	}{
+		Comments: comments,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	return centrifuge.RPCReply{Data: data}, nil
+}
@@ -0,0 +1,113 @@
+package ws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ws/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/centrifugal/centrifuge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func reviewMessage(eventType EventType, payload string) clientMessage {
+	return clientMessage{
+		Event: Event{Type: eventType, Payload: []byte(payload)},
+		PublishEvent: centrifuge.PublishEvent{
+			ClientInfo: &centrifuge.ClientInfo{UserID: "5"},
+		},
+	}
+}
+
+func TestManager_handleApproveComment(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	m := &Manager{log: logger.Plug(), commentService: commentService}
+
+	commentService.On("Approve", mock.Anything, commentservice.ReviewCommentDTO{UserID: 5, CommentID: "c1"}).
+		Return(domain.Comment{ID: "c1"}, nil)
+
+	reply, err := m.handleApproveComment(reviewMessage(EventApproveComment, `{"comment_id":"c1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, acceptedReply(), reply)
+}
+
+func TestManager_handleRejectComment(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	m := &Manager{log: logger.Plug(), commentService: commentService}
+
+	commentService.On("Reject", mock.Anything, commentservice.ReviewCommentDTO{UserID: 5, CommentID: "c1"}).Return(nil)
+
+	reply, err := m.handleRejectComment(reviewMessage(EventRejectComment, `{"comment_id":"c1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, acceptedReply(), reply)
+}
+
+func TestManager_handleReview_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		onApprove     error
+		expectedError error
+	}{
+		{
+			name:          "invalid payload",
+			payload:       `{"comment_id":`,
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "not pending",
+			payload:       `{"comment_id":"c1"}`,
+			onApprove:     domain.ErrCommentNotPending,
+			expectedError: domain.ErrCommentNotPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentService := mocks.NewCommentService(t)
+			m := &Manager{log: logger.Plug(), commentService: commentService}
+			if tt.onApprove != nil {
+				commentService.On("Approve", mock.Anything, mock.Anything).Return(domain.Comment{}, tt.onApprove)
+			}
+
+			_, err := m.handleApproveComment(reviewMessage(EventApproveComment, tt.payload))
+			require.Error(t, err)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_handleListPending(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	m, request := newModeratorRequest(t, commentService, EventListPending, `{"post_id":"p1","page":1,"page_size":10}`)
+
+	commentService.On("ListPending", mock.Anything, commentservice.ListPendingDTO{UserID: 5, PostID: "p1", Page: 1, PageSize: 10}).
+		Return([]domain.Comment{{ID: "c1", Body: "see https://a.kz"}}, domain.PaginationMetadata{TotalRecords: 1}, nil)
+
+	reply, err := m.handleListPending(request)
+	require.NoError(t, err)
+
+	var result struct {
+		Comments []domain.Comment `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(reply.Data, &result))
+	if assert.Len(t, result.Comments, 1) {
+		assert.Equal(t, "see https://a.kz", result.Comments[0].Body)
+	}
+}
+
+func TestManager_handleListPending_FailPath(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	m, request := newModeratorRequest(t, commentService, EventListPending, `{"post_id":"p1"}`)
+
+	commentService.On("ListPending", mock.Anything, mock.Anything).Return(nil, domain.PaginationMetadata{}, domain.ErrUnauthorized)
+
+	_, err := m.handleListPending(request)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
@@ -0,0 +1,105 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/centrifugal/centrifuge"
+)
+
+// removeCommentPayload is the payload of the remove_comment event
+type removeCommentPayload struct {
+	CommentID string `json:"comment_id"`
+}
+
+// HandleCommentEvent publishes the comment change to the channel of its post,
+// it is called for every change whatever path made it
+func (m *Manager) HandleCommentEvent(_ context.Context, commentEvent domain.CommentEvent) error {
+	event, err := eventFromCommentEvent(commentEvent)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if commentEvent.Type == domain.CommentMentioned {
+		return m.publishMentions(commentEvent.Mentioned, data)
+	}
+
+	_, err = m.node.Publish(
+		PostChannel(commentEvent.Comment.PostID), data,
+		centrifuge.WithHistory(300, time.Minute),
+	)
+	if err != nil {
+		return fmt.Errorf("error publishing message: %w", err)
+	}
+
+	return nil
+}
+
+// publishMentions sends the mention to the personal channels of the mentioned users,
+// a failed user does not stop the others
+func (m *Manager) publishMentions(mentioned []int64, data []byte) error {
+	var errs []error
+	for _, userID := range mentioned {
+		_, err := m.node.Publish(
+			PersonalChannel(strconv.FormatInt(userID, 10)), data,
+			centrifuge.WithHistory(100, time.Hour),
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error publishing mention to user %d: %w", userID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// eventFromCommentEvent returns the server event sent to the clients for the comment change
+func eventFromCommentEvent(commentEvent domain.CommentEvent) (Event, error) {
+	var (
+		eventType EventType
+		payload   any
+	)
+	switch commentEvent.Type {
+	case domain.CommentCreated:
+		eventType, payload = EventNewComment, commentEvent.Comment
+	case domain.CommentUpdated:
+		eventType, payload = EventEditComment, commentEvent.Comment
+	case domain.CommentMentioned:
+		eventType, payload = EventMentioned, commentEvent.Comment
+	case domain.CommentDeleted:
+		eventType, payload = EventRemoveComment, removeCommentPayload{CommentID: commentEvent.Comment.ID}
+	case domain.CommentReactionsUpdated:
+		eventType, payload = EventReactionUpdated, reactionUpdatedPayload{
+			CommentID: commentEvent.Comment.ID,
+			Reactions: commentEvent.Comment.Reactions,
+		}
+	default:
+		return Event{}, fmt.Errorf("%w: %s", ErrEventNotSupported, commentEvent.Type)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		Type:      eventType,
+		Payload:   data,
+		Timestamp: commentEvent.OccurredAt.Unix(),
+	}, nil
+}
+
+// acceptedReply tells centrifuge the client publication was handled, the resulting events are published
+// by HandleCommentEvent, so the raw client data must not be published to the channel
+func acceptedReply() centrifuge.PublishReply {
+	return centrifuge.PublishReply{Result: &centrifuge.PublishResult{}}
+}
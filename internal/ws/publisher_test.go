@@ -0,0 +1,88 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ws/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/centrifugal/centrifuge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_HandleCommentEvent(t *testing.T) {
+	tests := []struct {
+		name         string
+		eventType    domain.CommentEventType
+		expectedType EventType
+	}{
+		{name: "created", eventType: domain.CommentCreated, expectedType: EventNewComment},
+		{name: "updated", eventType: domain.CommentUpdated, expectedType: EventEditComment},
+		{name: "deleted", eventType: domain.CommentDeleted, expectedType: EventRemoveComment},
+		{name: "reactions updated", eventType: domain.CommentReactionsUpdated, expectedType: EventReactionUpdated},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewManager(logger.Plug(), config.Broker{}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+			require.NoError(t, err)
+			defer m.Stop(context.Background())
+
+			comment := domain.Comment{ID: domain.NewID(), PostID: domain.NewID(), Reactions: map[string]int32{"👍": 1}}
+			err = m.HandleCommentEvent(context.Background(), domain.NewCommentEvent(tc.eventType, comment, 1))
+			require.NoError(t, err)
+
+			history, err := m.node.History(PostChannel(comment.PostID), centrifuge.WithLimit(centrifuge.NoLimit))
+			require.NoError(t, err)
+			require.Len(t, history.Publications, 1)
+
+			var event Event
+			require.NoError(t, json.Unmarshal(history.Publications[0].Data, &event))
+			assert.Equal(t, tc.expectedType, event.Type)
+
+			var payload struct {
+				ID        string `json:"id"`
+				CommentID string `json:"comment_id"`
+			}
+			require.NoError(t, json.Unmarshal(event.Payload, &payload))
+			assert.Contains(t, []string{payload.ID, payload.CommentID}, comment.ID)
+		})
+	}
+}
+
+func TestManager_HandleCommentEvent_Mention(t *testing.T) {
+	m, err := NewManager(logger.Plug(), config.Broker{}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	defer m.Stop(context.Background())
+
+	comment := domain.Comment{ID: domain.NewID(), PostID: domain.NewID(), Body: "@id:2 @id:3"}
+	err = m.HandleCommentEvent(context.Background(), domain.NewMentionEvent(comment, 1, []int64{2, 3}))
+	require.NoError(t, err)
+
+	for _, userID := range []string{"2", "3"} {
+		history, err := m.node.History(PersonalChannel(userID), centrifuge.WithLimit(centrifuge.NoLimit))
+		require.NoError(t, err)
+		require.Len(t, history.Publications, 1)
+
+		var event Event
+		require.NoError(t, json.Unmarshal(history.Publications[0].Data, &event))
+		assert.Equal(t, EventMentioned, event.Type)
+	}
+
+	history, err := m.node.History(PostChannel(comment.PostID), centrifuge.WithLimit(centrifuge.NoLimit))
+	require.NoError(t, err)
+	assert.Empty(t, history.Publications, "mentions are not published to the post channel")
+}
+
+func TestManager_HandleCommentEvent_FailPath(t *testing.T) {
+	m, err := NewManager(logger.Plug(), config.Broker{}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	defer m.Stop(context.Background())
+
+	err = m.HandleCommentEvent(context.Background(), domain.NewCommentEvent("comment.unknown", domain.Comment{PostID: "p1"}, 1))
+	assert.ErrorIs(t, err, ErrEventNotSupported)
+}
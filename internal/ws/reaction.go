@@ -0,0 +1,68 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+	"github.com/centrifugal/centrifuge"
+)
+
+// reactionUpdatedPayload is the payload of the reaction_updated event
+type reactionUpdatedPayload struct {
+	CommentID string           `json:"comment_id"`
+	Reactions map[string]int32 `json:"reactions"`
+}
+
+// handleAddReaction is an event handler that is triggered when a client sends an add_reaction event
+func (m *Manager) handleAddReaction(message clientMessage) (centrifuge.PublishReply, error) {
+	return m.handleReaction(message, m.commentService.AddReaction)
+}
+
+// handleRemoveReaction is an event handler that is triggered when a client sends a remove_reaction event
+func (m *Manager) handleRemoveReaction(message clientMessage) (centrifuge.PublishReply, error) {
+	return m.handleReaction(message, m.commentService.RemoveReaction)
+}
+
+// handleReaction applies the reaction change, the service emits the new counters of the comment
+func (m *Manager) handleReaction(
+	message clientMessage,
+	apply func(ctx context.Context, dto commentservice.ReactionDTO) (domain.Comment, error),
+) (centrifuge.PublishReply, error) {
+	var input struct {
+		CommentID string `json:"comment_id"`
+		Emoji     string `json:"emoji"`
+	}
+	err := json.Unmarshal(message.Event.Payload, &input)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+
+	userID, err := strconv.ParseInt(message.PublishEvent.ClientInfo.UserID, 10, 64)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = m.authorizeChannelComment(ctx, userID, message.PublishEvent.Channel, input.CommentID)
+	if err != nil {
+		return centrifuge.PublishReply{}, err
+	}
+
+	_, err = apply(ctx, commentservice.ReactionDTO{
+		CommentID: input.CommentID,
+		Emoji:     input.Emoji,
+		UserID:    userID,
+	})
+	if err != nil {
+		return centrifuge.PublishReply{}, err
+	}
+
+	return acceptedReply(), nil
+}
@@ -0,0 +1,99 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ws/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/centrifugal/centrifuge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func reactionMessage(eventType EventType, channel, payload string) clientMessage {
+	return clientMessage{
+		Event: Event{Type: eventType, Payload: []byte(payload)},
+		PublishEvent: centrifuge.PublishEvent{
+			Channel:    channel,
+			ClientInfo: &centrifuge.ClientInfo{UserID: "2"},
+		},
+	}
+}
+
+func TestManager_handleAddReaction(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	accessChecker := mocks.NewAccessChecker(t)
+	m := &Manager{log: logger.Plug(), commentService: commentService, accessChecker: accessChecker}
+
+	commentService.On("GetByID", mock.Anything, "c1").Return(domain.Comment{ID: "c1", PostID: "p1"}, nil)
+	accessChecker.On("CanViewPost", mock.Anything, int64(2), "p1").Return(true, nil)
+	commentService.On("AddReaction", mock.Anything, commentservice.ReactionDTO{UserID: 2, CommentID: "c1", Emoji: "👍"}).
+		Return(domain.Comment{ID: "c1"}, nil)
+
+	reply, err := m.handleAddReaction(reactionMessage(EventAddReaction, PostChannel("p1"), `{"comment_id":"c1","emoji":"👍"}`))
+	require.NoError(t, err)
+	assert.Equal(t, acceptedReply(), reply)
+}
+
+func TestManager_handleRemoveReaction_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		channel       string
+		payload       string
+		loadsComment  bool
+		onGetByID     error
+		checksView    bool
+		expectedError error
+	}{
+		{
+			name:          "invalid payload",
+			channel:       PostChannel("p1"),
+			payload:       `{"comment_id":`,
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "comment not found",
+			channel:       PostChannel("p1"),
+			payload:       `{"comment_id":"c1","emoji":"👍"}`,
+			loadsComment:  true,
+			onGetByID:     domain.ErrCommentNotFound,
+			expectedError: domain.ErrCommentNotFound,
+		},
+		{
+			name:          "comment of another post",
+			channel:       PostChannel("p2"),
+			payload:       `{"comment_id":"c1","emoji":"👍"}`,
+			loadsComment:  true,
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "post not visible",
+			channel:       PostChannel("p1"),
+			payload:       `{"comment_id":"c1","emoji":"👍"}`,
+			loadsComment:  true,
+			checksView:    true,
+			expectedError: domain.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentService := mocks.NewCommentService(t)
+			accessChecker := mocks.NewAccessChecker(t)
+			m := &Manager{log: logger.Plug(), commentService: commentService, accessChecker: accessChecker}
+			if tt.loadsComment {
+				commentService.On("GetByID", mock.Anything, "c1").Return(domain.Comment{ID: "c1", PostID: "p1"}, tt.onGetByID)
+			}
+			if tt.checksView {
+				accessChecker.On("CanViewPost", mock.Anything, int64(2), "p1").Return(false, nil)
+			}
+
+			_, err := m.handleRemoveReaction(reactionMessage(EventRemoveReaction, tt.channel, tt.payload))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+	"github.com/centrifugal/centrifuge"
+)
+
+// handleReportComment is an event handler that is triggered when a client sends a report_comment event,
+// the report is only seen by the moderators, nothing is published to the channel
+func (m *Manager) handleReportComment(message clientMessage) (centrifuge.PublishReply, error) {
+	var input struct {
+		CommentID string `json:"comment_id"`
+		Reason    string `json:"reason"`
+	}
+	err := json.Unmarshal(message.Event.Payload, &input)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+
+	userID, err := strconv.ParseInt(message.PublishEvent.ClientInfo.UserID, 10, 64)
+	if err != nil {
+		return centrifuge.PublishReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = m.authorizeChannelComment(ctx, userID, message.PublishEvent.Channel, input.CommentID)
+	if err != nil {
+		return centrifuge.PublishReply{}, err
+	}
+
+	_, err = m.commentService.Report(ctx, commentservice.ReportCommentDTO{
+		CommentID: input.CommentID,
+		Reason:    input.Reason,
+		UserID:    userID,
+	})
+	if err != nil {
+		return centrifuge.PublishReply{}, err
+	}
+
+	return acceptedReply(), nil
+}
+
+// handleListReports is a request handler that is triggered when a client calls the list_reports rpc,
+// it returns the moderation queue of either a club or a post, only their moderators can list it
+func (m *Manager) handleListReports(request clientRequest) (centrifuge.RPCReply, error) {
+	var input struct {
+		ClubID   int64  `json:"club_id"`
+		PostID   string `json:"post_id"`
+		Page     int32  `json:"page"`
+		PageSize int32  `json:"page_size"`
+	}
+	err := json.Unmarshal(request.RPCEvent.Data, &input)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+
+	userID, err := strconv.ParseInt(request.Client.UserID(), 10, 64)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reports, metadata, err := m.commentService.ListReports(ctx, commentservice.ListReportsDTO{
+		UserID:   userID,
+		ClubID:   input.ClubID,
+		PostID:   input.PostID,
+		Page:     input.Page,
+		PageSize: input.PageSize,
+	})
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	data, err := json.Marshal(struct {
+		Reports  []domain.Report           `json:"reports"`
+		Metadata domain.PaginationMetadata `json:"metadata"`
+	}{
+		Reports:  reports,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	return centrifuge.RPCReply{Data: data}, nil
+}
+
+// handleResolveReport is a request handler that is triggered when a client calls the resolve_report rpc,
+// the decision of the moderator is applied to the reported comment and the changed comment is broadcast as usual
+func (m *Manager) handleResolveReport(request clientRequest) (centrifuge.RPCReply, error) {
+	var input struct {
+		ReportID string `json:"report_id"`
+		Action   string `json:"action"`
+	}
+	err := json.Unmarshal(request.RPCEvent.Data, &input)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+
+	action, err := domain.ParseReportAction(input.Action)
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	userID, err := strconv.ParseInt(request.Client.UserID(), 10, 64)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("error converting UserID to int64: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	report, err := m.commentService.ResolveReport(ctx, commentservice.ResolveReportDTO{
+		UserID:   userID,
+		ReportID: input.ReportID,
+		Action:   action,
+	})
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	data, err := json.Marshal(struct {
+		Report domain.Report `json:"report"`
+	}{
+		Report: report,
+	})
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	return centrifuge.RPCReply{Data: data}, nil
+}
@@ -0,0 +1,225 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ws/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/centrifugal/centrifuge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func reportMessage(payload string) clientMessage {
+	return clientMessage{
+		Event: Event{Type: EventReportComment, Payload: []byte(payload)},
+		PublishEvent: centrifuge.PublishEvent{
+			Channel:    PostChannel("p1"),
+			ClientInfo: &centrifuge.ClientInfo{UserID: "2"},
+		},
+	}
+}
+
+func TestManager_handleReportComment(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	accessChecker := mocks.NewAccessChecker(t)
+	m := &Manager{log: logger.Plug(), commentService: commentService, accessChecker: accessChecker}
+
+	commentService.On("GetByID", mock.Anything, "c1").Return(domain.Comment{ID: "c1", PostID: "p1"}, nil)
+	accessChecker.On("CanViewPost", mock.Anything, int64(2), "p1").Return(true, nil)
+
+	commentService.On("Report", mock.Anything, commentservice.ReportCommentDTO{UserID: 2, CommentID: "c1", Reason: "spam"}).
+		Return(domain.Report{ID: "r1"}, nil)
+
+	reply, err := m.handleReportComment(reportMessage(`{"comment_id":"c1","reason":"spam"}`))
+	require.NoError(t, err)
+	assert.Equal(t, acceptedReply(), reply)
+}
+
+func TestManager_handleReportComment_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		postID        string
+		allowed       bool
+		onReport      error
+		expectedError error
+	}{
+		{
+			name:          "invalid payload",
+			payload:       `{"comment_id":`,
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "comment of another post",
+			payload:       `{"comment_id":"c1"}`,
+			postID:        "p2",
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "post not visible",
+			payload:       `{"comment_id":"c1"}`,
+			postID:        "p1",
+			expectedError: domain.ErrUnauthorized,
+		},
+		{
+			name:          "already reported",
+			payload:       `{"comment_id":"c1"}`,
+			postID:        "p1",
+			allowed:       true,
+			onReport:      domain.ErrAlreadyReported,
+			expectedError: domain.ErrAlreadyReported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentService := mocks.NewCommentService(t)
+			accessChecker := mocks.NewAccessChecker(t)
+			m := &Manager{log: logger.Plug(), commentService: commentService, accessChecker: accessChecker}
+			if tt.postID != "" {
+				commentService.On("GetByID", mock.Anything, "c1").Return(domain.Comment{ID: "c1", PostID: tt.postID}, nil)
+			}
+			if tt.postID == "p1" {
+				accessChecker.On("CanViewPost", mock.Anything, int64(2), "p1").Return(tt.allowed, nil)
+			}
+			if tt.onReport != nil {
+				commentService.On("Report", mock.Anything, mock.Anything).Return(domain.Report{}, tt.onReport)
+			}
+
+			_, err := m.handleReportComment(reportMessage(tt.payload))
+			require.Error(t, err)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+// newModeratorRequest returns the rpc call of the connected moderator with the id 5
+func newModeratorRequest(t *testing.T, commentService CommentService, method EventType, data string) (*Manager, clientRequest) {
+	m, err := NewManager(logger.Plug(), config.Broker{}, commentService, mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = m.Stop(context.Background()) })
+
+	client, _ := connectTestClient(t, m, 5)
+	return m, clientRequest{Client: client, RPCEvent: centrifuge.RPCEvent{Method: string(method), Data: []byte(data)}}
+}
+
+func TestManager_handleListReports(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	m, request := newModeratorRequest(t, commentService, EventListReports, `{"club_id":3,"page":1,"page_size":10}`)
+
+	commentService.On("ListReports", mock.Anything, commentservice.ListReportsDTO{UserID: 5, ClubID: 3, Page: 1, PageSize: 10}).
+		Return([]domain.Report{{ID: "r1", CommentID: "c1", ClubID: 3, Status: domain.ReportOpen}}, domain.PaginationMetadata{CurrentPage: 1, PageSize: 10, TotalRecords: 1}, nil)
+
+	reply, err := m.handleListReports(request)
+	require.NoError(t, err)
+
+	var result struct {
+		Reports  []domain.Report           `json:"reports"`
+		Metadata domain.PaginationMetadata `json:"metadata"`
+	}
+	require.NoError(t, json.Unmarshal(reply.Data, &result))
+	if assert.Len(t, result.Reports, 1) {
+		assert.Equal(t, "r1", result.Reports[0].ID)
+	}
+	assert.Equal(t, int32(1), result.Metadata.TotalRecords)
+}
+
+func TestManager_handleListReports_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		onList        error
+		expectedError error
+	}{
+		{
+			name:          "invalid data",
+			data:          `{"club_id":`,
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "not a moderator",
+			data:          `{"post_id":"p1"}`,
+			onList:        domain.ErrUnauthorized,
+			expectedError: domain.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentService := mocks.NewCommentService(t)
+			m, request := newModeratorRequest(t, commentService, EventListReports, tt.data)
+			if tt.onList != nil {
+				commentService.On("ListReports", mock.Anything, mock.Anything).Return(nil, domain.PaginationMetadata{}, tt.onList)
+			}
+
+			_, err := m.handleListReports(request)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestManager_handleResolveReport(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	m, request := newModeratorRequest(t, commentService, EventResolveReport, `{"report_id":"r1","action":"hide"}`)
+
+	commentService.On("ResolveReport", mock.Anything, commentservice.ResolveReportDTO{UserID: 5, ReportID: "r1", Action: domain.ReportActionHide}).
+		Return(domain.Report{ID: "r1", Status: domain.ReportHidden}, nil)
+
+	reply, err := m.handleResolveReport(request)
+	require.NoError(t, err)
+
+	var result struct {
+		Report domain.Report `json:"report"`
+	}
+	require.NoError(t, json.Unmarshal(reply.Data, &result))
+	assert.Equal(t, "r1", result.Report.ID)
+	assert.Equal(t, domain.ReportHidden, result.Report.Status)
+}
+
+func TestManager_handleResolveReport_FailPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		onResolve     error
+		expectedError error
+	}{
+		{
+			name:          "invalid data",
+			data:          `{"report_id":`,
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "unknown action",
+			data:          `{"report_id":"r1","action":"ban"}`,
+			expectedError: domain.ErrInvalidReportAction,
+		},
+		{
+			name:          "already resolved",
+			data:          `{"report_id":"r1","action":"dismiss"}`,
+			onResolve:     domain.ErrReportResolved,
+			expectedError: domain.ErrReportResolved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentService := mocks.NewCommentService(t)
+			m, request := newModeratorRequest(t, commentService, EventResolveReport, tt.data)
+			if tt.onResolve != nil {
+				commentService.On("ResolveReport", mock.Anything, mock.Anything).Return(domain.Report{}, tt.onResolve)
+			}
+
+			_, err := m.handleResolveReport(request)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/centrifugal/centrifuge"
+)
+
+// Sync sources tell the client where the missed events were recovered from
+const (
+	syncSourceHistory = "history"
+	syncSourceStorage = "storage"
+)
+
+// syncReply is the reply of the sync_comments request
+type syncReply struct {
+	Events []Event `json:"events"`
+	// Source is history when the events come from the channel history and storage when they were rebuilt from the database
+	Source string `json:"source"`
+	// Complete is false when there were too many changes to return, the client syncs again from Cursor or reloads the post
+	Complete bool `json:"complete"`
+	// Cursor is the position of the last rebuilt change, the resume point of the next sync when Complete is false
+	Cursor string `json:"cursor,omitempty"`
+	// Offset and Epoch are the current position of the channel stream, they are used in the next sync
+	Offset uint64 `json:"offset"`
+	Epoch  string `json:"epoch"`
+}
+
+// handleSyncComments is a request handler that is triggered when a client calls the sync_comments rpc
+//
+// It returns the events of the post channel the client missed while it was offline, the client must be subscribed to it. The channel history is used
+// when it still holds the stream position last seen by the client, otherwise the events are rebuilt from
+// the comments changed since the given unix timestamp, or after the cursor of the previous incomplete sync.
+func (m *Manager) handleSyncComments(request clientRequest) (centrifuge.RPCReply, error) {
+	var input struct {
+		PostID string `json:"post_id"`
+		Since  int64  `json:"since"`
+		Cursor string `json:"cursor"`
+		Offset uint64 `json:"offset"`
+		Epoch  string `json:"epoch"`
+	}
+	err := json.Unmarshal(request.RPCEvent.Data, &input)
+	if err != nil {
+		return centrifuge.RPCReply{}, fmt.Errorf("%w: %w", domain.ErrInvalidArg, err)
+	}
+	if input.PostID == "" {
+		return centrifuge.RPCReply{}, fmt.Errorf("%w: post_id is required", domain.ErrInvalidArg)
+	}
+
+	channel := PostChannel(input.PostID)
+	if !request.Client.IsSubscribed(channel) {
+		return centrifuge.RPCReply{}, domain.ErrUnauthorized
+	}
+
+	var reply syncReply
+	recovered := false
+	if input.Epoch != "" {
+		reply, recovered, err = m.syncFromHistory(channel, centrifuge.StreamPosition{
+			Offset: input.Offset,
+			Epoch:  input.Epoch,
+		})
+		if err != nil {
+			return centrifuge.RPCReply{}, err
+		}
+	}
+
+	if !recovered {
+		after := domain.SyncCursor{ChangedAt: time.Unix(input.Since, 0)}
+		switch {
+		case input.Cursor != "":
+			after, err = domain.DecodeSyncCursor(input.Cursor)
+			if err != nil {
+				return centrifuge.RPCReply{}, err
+			}
+		case input.Since <= 0:
+			return centrifuge.RPCReply{}, fmt.Errorf("%w: since is required when the history can not be used", domain.ErrInvalidArg)
+		}
+
+		reply, err = m.syncFromStorage(channel, input.PostID, after)
+		if err != nil {
+			return centrifuge.RPCReply{}, err
+		}
+	}
+
+	data, err := json.Marshal(reply)
+	if err != nil {
+		return centrifuge.RPCReply{}, err
+	}
+
+	return centrifuge.RPCReply{Data: data}, nil
+}
+
+// syncFromHistory returns the publications of the channel after the position,
+// recovered is false when the history does not reach back to it anymore
+func (m *Manager) syncFromHistory(channel string, since centrifuge.StreamPosition) (syncReply, bool, error) {
+	result, err := m.node.History(channel, centrifuge.WithSince(&since), centrifuge.WithLimit(centrifuge.NoLimit))
+	if err != nil {
+		if errors.Is(err, centrifuge.ErrorUnrecoverablePosition) {
+			return syncReply{}, false, nil
+		}
+		return syncReply{}, false, fmt.Errorf("error reading history: %w", err)
+	}
+
+	// the history is complete only if nothing between the position and the first kept publication expired
+	if len(result.Publications) == 0 && since.Offset != result.Offset ||
+		len(result.Publications) > 0 && result.Publications[0].Offset != since.Offset+1 {
+		return syncReply{}, false, nil
+	}
+
+	events := make([]Event, 0, len(result.Publications))
+	for _, publication := range result.Publications {
+		var event Event
+		err = json.Unmarshal(publication.Data, &event)
+		if err != nil {
+			return syncReply{}, false, fmt.Errorf("error decoding publication: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return syncReply{
+		Events:   events,
+		Source:   syncSourceHistory,
+		Complete: true,
+		Offset:   result.Offset,
+		Epoch:    result.Epoch,
+	}, true, nil
+}
+
+// syncFromStorage rebuilds the events of the comments changed after the cursor
+func (m *Manager) syncFromStorage(channel, postID string, after domain.SyncCursor) (syncReply, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := m.commentService.Sync(ctx, postID, after)
+	if err != nil {
+		return syncReply{}, err
+	}
+
+	events := make([]Event, 0, len(result.Comments))
+	for _, comment := range result.Comments {
+		event, err := eventFromComment(comment, after.ChangedAt)
+		if err != nil {
+			return syncReply{}, err
+		}
+		events = append(events, event)
+	}
+
+	// the position lets the next sync use the history again
+	top, err := m.node.History(channel)
+	if err != nil {
+		return syncReply{}, fmt.Errorf("error reading history position: %w", err)
+	}
+
+	reply := syncReply{
+		Events:   events,
+		Source:   syncSourceStorage,
+		Complete: result.Complete,
+		Offset:   top.Offset,
+		Epoch:    top.Epoch,
+	}
+	if !result.Complete {
+		reply.Cursor = result.Next.Encode()
+	}
+
+	return reply, nil
+}
+
+// eventFromComment returns the server event matching the latest change of the comment
+func eventFromComment(comment domain.Comment, since time.Time) (Event, error) {
+	var (
+		eventType EventType
+		payload   []byte
+		err       error
+	)
+	switch {
+	case comment.IsDeleted():
+		eventType = EventRemoveComment
+		payload, err = json.Marshal(struct {
+			CommentID string `json:"comment_id"`
+		}{CommentID: comment.ID})
+	case !comment.CreatedAt.Before(since):
+		eventType = EventNewComment
+		payload, err = json.Marshal(comment)
+	default:
+		eventType = EventEditComment
+		payload, err = json.Marshal(comment)
+	}
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		Type:      eventType,
+		Payload:   payload,
+		Timestamp: comment.ChangedAt().Unix(),
+	}, nil
+}
@@ -0,0 +1,191 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/config"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/services/commentservice"
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/ws/mocks"
+	"github.com/ARUMANDESU/uniclubs-comments-service/pkg/logger"
+	"github.com/centrifugal/centrifuge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSyncRequest(t *testing.T, client *centrifuge.Client, data any) clientRequest {
+	raw, err := json.Marshal(data)
+	require.NoError(t, err)
+	return clientRequest{Client: client, RPCEvent: centrifuge.RPCEvent{Method: string(EventSyncComments), Data: raw}}
+}
+
+func publishEvent(t *testing.T, m *Manager, channel string, eventType EventType) centrifuge.PublishResult {
+	data, err := json.Marshal(Event{Type: eventType, Payload: json.RawMessage(`{}`), Timestamp: time.Now().Unix()})
+	require.NoError(t, err)
+	result, err := m.node.Publish(channel, data, centrifuge.WithHistory(300, time.Minute))
+	require.NoError(t, err)
+	return result
+}
+
+// newSubscribedClient connects a client subscribed to the post channel
+func newSubscribedClient(t *testing.T, m *Manager, postID string) *centrifuge.Client {
+	client, _ := connectTestClient(t, m, 1)
+	require.NoError(t, client.Subscribe(PostChannel(postID)))
+	return client
+}
+
+func TestManager_handleSyncComments_History(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	m, err := NewManager(logger.Plug(), config.Broker{}, commentService, mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	defer m.Stop(context.Background())
+
+	postID := domain.NewID()
+	client := newSubscribedClient(t, m, postID)
+
+	first := publishEvent(t, m, PostChannel(postID), EventNewComment)
+	publishEvent(t, m, PostChannel(postID), EventEditComment)
+	publishEvent(t, m, PostChannel(postID), EventRemoveComment)
+
+	reply, err := m.handleSyncComments(newSyncRequest(t, client, map[string]any{
+		"post_id": postID,
+		"offset":  first.Offset,
+		"epoch":   first.Epoch,
+	}))
+	require.NoError(t, err)
+
+	var result syncReply
+	require.NoError(t, json.Unmarshal(reply.Data, &result))
+	assert.Equal(t, syncSourceHistory, result.Source)
+	assert.True(t, result.Complete)
+	assert.Equal(t, first.Offset+2, result.Offset)
+	if assert.Len(t, result.Events, 2) {
+		assert.Equal(t, EventEditComment, result.Events[0].Type)
+		assert.Equal(t, EventRemoveComment, result.Events[1].Type)
+	}
+}
+
+func TestManager_handleSyncComments_StorageFallback(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	m, err := NewManager(logger.Plug(), config.Broker{}, commentService, mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	defer m.Stop(context.Background())
+
+	postID := domain.NewID()
+	client := newSubscribedClient(t, m, postID)
+
+	since := time.Now().Add(-time.Hour).Truncate(time.Second)
+	deletedAt := since.Add(30 * time.Minute)
+	comments := []domain.Comment{
+		{ID: "1", CreatedAt: since.Add(-time.Hour), UpdatedAt: since.Add(10 * time.Minute)},
+		{ID: "2", CreatedAt: since.Add(20 * time.Minute), UpdatedAt: since.Add(20 * time.Minute)},
+		{ID: "3", CreatedAt: since.Add(-time.Hour), UpdatedAt: since.Add(-time.Hour), DeletedAt: &deletedAt},
+	}
+	commentService.On("Sync", mock.Anything, postID, domain.SyncCursor{ChangedAt: since}).
+		Return(commentservice.SyncResult{Comments: comments, Complete: true, Next: domain.SyncCursor{ChangedAt: deletedAt, ID: "3"}}, nil)
+
+	reply, err := m.handleSyncComments(newSyncRequest(t, client, map[string]any{
+		"post_id": postID,
+		"since":   since.Unix(),
+		"epoch":   "unknown",
+	}))
+	require.NoError(t, err)
+
+	var result syncReply
+	require.NoError(t, json.Unmarshal(reply.Data, &result))
+	assert.Equal(t, syncSourceStorage, result.Source)
+	assert.True(t, result.Complete)
+	assert.Empty(t, result.Cursor)
+	if assert.Len(t, result.Events, 3) {
+		assert.Equal(t, EventEditComment, result.Events[0].Type)
+		assert.Equal(t, EventNewComment, result.Events[1].Type)
+		assert.Equal(t, EventRemoveComment, result.Events[2].Type)
+		assert.JSONEq(t, `{"comment_id": "3"}`, string(result.Events[2].Payload))
+	}
+}
+
+func TestManager_handleSyncComments_StorageCursor(t *testing.T) {
+	commentService := mocks.NewCommentService(t)
+	m, err := NewManager(logger.Plug(), config.Broker{}, commentService, mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+	require.NoError(t, err)
+	defer m.Stop(context.Background())
+
+	postID := domain.NewID()
+	client := newSubscribedClient(t, m, postID)
+
+	changedAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UTC()
+	after := domain.SyncCursor{ChangedAt: changedAt, ID: domain.NewID()}
+	next := domain.SyncCursor{ChangedAt: changedAt, ID: domain.NewID()}
+	comments := []domain.Comment{{ID: next.ID, CreatedAt: changedAt.Add(-time.Hour), UpdatedAt: changedAt}}
+	commentService.On("Sync", mock.Anything, postID, after).Return(commentservice.SyncResult{Comments: comments, Next: next}, nil)
+
+	reply, err := m.handleSyncComments(newSyncRequest(t, client, map[string]any{
+		"post_id": postID,
+		"cursor":  after.Encode(),
+	}))
+	require.NoError(t, err)
+
+	var result syncReply
+	require.NoError(t, json.Unmarshal(reply.Data, &result))
+	assert.False(t, result.Complete)
+	// the changes at the same time as the cursor are resumed after the last returned comment
+	assert.Equal(t, next.Encode(), result.Cursor)
+	if assert.Len(t, result.Events, 1) {
+		assert.Equal(t, EventEditComment, result.Events[0].Type)
+	}
+}
+
+func TestManager_handleSyncComments_FailPath(t *testing.T) {
+	postID := domain.NewID()
+
+	tests := []struct {
+		name          string
+		subscribe     bool
+		data          map[string]any
+		expectedError error
+	}{
+		{
+			name:          "missing post id",
+			subscribe:     true,
+			data:          map[string]any{"since": 1},
+			expectedError: domain.ErrInvalidArg,
+		},
+		{
+			name:          "not subscribed to the post channel",
+			data:          map[string]any{"post_id": postID, "since": 1},
+			expectedError: domain.ErrUnauthorized,
+		},
+		{
+			name:          "invalid cursor",
+			subscribe:     true,
+			data:          map[string]any{"post_id": postID, "cursor": "%%%"},
+			expectedError: domain.ErrInvalidCursor,
+		},
+		{
+			name:          "missing since without history position",
+			subscribe:     true,
+			data:          map[string]any{"post_id": postID},
+			expectedError: domain.ErrInvalidArg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewManager(logger.Plug(), config.Broker{}, mocks.NewCommentService(t), mocks.NewAccessChecker(t), testTokenVerifier(), revokedUsers{})
+			require.NoError(t, err)
+			defer m.Stop(context.Background())
+
+			client, _ := connectTestClient(t, m, 1)
+			if tt.subscribe {
+				require.NoError(t, client.Subscribe(PostChannel(postID)))
+			}
+
+			_, err = m.handleSyncComments(newSyncRequest(t, client, tt.data))
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Package commentevents defines the JSON messages the comment service publishes to the comment-exchange.
+//
+// Every message is an Envelope, consumers switch on its Type and Version before decoding Data. A version only
+// gets new optional fields, a breaking change of the data is published under the next version.
+package commentevents
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The event types, every message is published with its type as the routing key
+const (
+	TypeCreated   = "comment.event.created"
+	TypeUpdated   = "comment.event.updated"
+	TypeDeleted   = "comment.event.deleted"
+	TypeMentioned = "comment.event.mentioned"
+)
+
+// Version is the current version of the data of every event type
+const Version = 1
+
+// Envelope is the message published for every event
+type Envelope struct {
+	// ID is unique per event, consumers use it to drop the redelivered messages
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+	// ActorID is the id of the user who made the change, 0 when the service made it, e.g. hiding a reported comment
+	ActorID    int64           `json:"actor_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// NewEnvelope returns the envelope of the event with the data encoded
+func NewEnvelope(id, eventType string, actorID int64, occurredAt time.Time, data any) (Envelope, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return Envelope{
+		ID:         id,
+		Type:       eventType,
+		Version:    Version,
+		ActorID:    actorID,
+		OccurredAt: occurredAt,
+		Data:       encoded,
+	}, nil
+}
+
+// CommentV1 is the data of comment.event.created and comment.event.updated.
+// The comment is as users see it: the body of hidden, pending and deleted comments is replaced with a placeholder.
+type CommentV1 struct {
+	ID       string `json:"id"`
+	PostID   string `json:"post_id"`
+	ParentID string `json:"parent_id,omitempty"`
+	Depth    int32  `json:"depth"`
+	Author   UserV1 `json:"author"`
+	Body     string `json:"body"`
+	Edited   bool   `json:"edited"`
+	// Hidden is set while a moderator or the reports keep the comment hidden
+	Hidden bool `json:"hidden"`
+	// ModerationStatus is approved, masked or pending
+	ModerationStatus string           `json:"moderation_status"`
+	Reactions        map[string]int32 `json:"reactions,omitempty"`
+	Mentions         []int64          `json:"mentions,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+}
+
+// UserV1 is the author of the comment as it was when the comment was last stored
+type UserV1 struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// DeletedV1 is the data of comment.event.deleted, the comment is soft deleted and can still be restored,
+// a restored comment is published as comment.event.updated
+type DeletedV1 struct {
+	ID        string    `json:"id"`
+	PostID    string    `json:"post_id"`
+	ParentID  string    `json:"parent_id,omitempty"`
+	DeletedBy int64     `json:"deleted_by"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// MentionedV1 is the data of comment.event.mentioned, every user of MentionedUserIDs is to be notified
+type MentionedV1 struct {
+	Comment          CommentV1 `json:"comment"`
+	MentionedUserIDs []int64   `json:"mentioned_user_ids"`
+}
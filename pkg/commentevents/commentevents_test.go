@@ -0,0 +1,33 @@
+package commentevents
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnvelope(t *testing.T) {
+	occurredAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	envelope, err := NewEnvelope("e1", TypeDeleted, 7, occurredAt, DeletedV1{ID: "c1", PostID: "p1", DeletedBy: 7, DeletedAt: occurredAt})
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "e1",
+		"type": "comment.event.deleted",
+		"version": 1,
+		"actor_id": 7,
+		"occurred_at": "2024-06-01T12:00:00Z",
+		"data": {"id": "c1", "post_id": "p1", "deleted_by": 7, "deleted_at": "2024-06-01T12:00:00Z"}
+	}`, string(encoded))
+}
+
+func TestNewEnvelope_FailPath(t *testing.T) {
+	_, err := NewEnvelope("e1", TypeCreated, 1, time.Now(), make(chan int))
+	assert.Error(t, err)
+}
@@ -1,67 +1,150 @@
 package jwt
 
 import (
+	"errors"
 	"fmt"
-	"log/slog"
-	"os"
-	"strings"
+	"slices"
 	"time"
 
 	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// GetUserID extracts the user_id from the provided JWT token
-// returns the user_id, expiresAt and an error if the token is not valid
-func GetUserID(tokenString, secret string) (int64, int64, error) {
-	const op = "jwt.GetUserID"
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		// Return the secret used to sign the token
-		return []byte(secret), nil
-	})
-	if err != nil {
-		errorMessage := err.Error()
-		switch {
-		case strings.Contains(errorMessage, "token is expired"):
-			return 0, 0, domain.ErrTokenIsExpired
-		case strings.Contains(errorMessage, "token contains an invalid number of segments"):
-			return 0, 0, domain.ErrTokenIsNotValid
-		case strings.Contains(errorMessage, "unexpected signing method"):
-			return 0, 0, domain.ErrUnexpectedSigningMethod
+// Claims are the claims of a verified user token
+type Claims struct {
+	UserID int64
+	// Roles are the platform roles of the user, e.g. ADMIN or MODER
+	Roles []string
+	// ClubIDs are the clubs the user is a member of
+	ClubIDs   []int64
+	ExpiresAt time.Time
+}
+
+// HasRole reports whether the user has one of the roles
+func (c Claims) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if slices.Contains(c.Roles, role) {
+			return true
 		}
-		return 0, 0, fmt.Errorf("%s: %w", op, err)
 	}
+	return false
+}
 
-	// Check if the token is valid
-	if !token.Valid {
-		return 0, 0, domain.ErrTokenIsNotValid
+// tokenClaims is the payload of the user token
+type tokenClaims struct {
+	UserID  *int64   `json:"user_id"`
+	Roles   []string `json:"roles"`
+	ClubIDs []int64  `json:"club_ids"`
+	jwt.RegisteredClaims
+}
+
+// Validation configures the checks of the registered claims, empty values skip the check
+type Validation struct {
+	// Issuer is the expected iss claim
+	Issuer string
+	// Audience is the audience the aud claim must contain
+	Audience string
+	// Leeway is the allowed clock skew for the exp, nbf and iat claims
+	Leeway time.Duration
+}
+
+// Verifier checks the signature of the tokens with the keys of its key source
+type Verifier struct {
+	keyFunc jwt.Keyfunc
+	// methods are the signing algorithms accepted by the verifier, tokens signed otherwise are rejected
+	methods    []string
+	validation Validation
+}
+
+// NewHMACVerifier returns the verifier of the tokens signed with the shared secret
+func NewHMACVerifier(secret string) *Verifier {
+	return &Verifier{
+		keyFunc: func(token *jwt.Token) (interface{}, error) {
+			// Return the secret used to sign the token
+			return []byte(secret), nil
+		},
+		methods: []string{
+			jwt.SigningMethodHS256.Alg(),
+			jwt.SigningMethodHS384.Alg(),
+			jwt.SigningMethodHS512.Alg(),
+		},
 	}
+}
 
-	// Extract claims
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return 0, 0, domain.ErrInvalidTokenClaims
+// WithValidation sets the checks of the registered claims and returns the verifier
+func (v *Verifier) WithValidation(validation Validation) *Verifier {
+	v.validation = validation
+	return v
+}
+
+// Verify checks the token and returns its claims, the errors are the domain token errors
+// except ErrKeyUnavailable when the verification key cannot be loaded
+func (v *Verifier) Verify(tokenString string) (Claims, error) {
+	const op = "jwt.Verify"
+
+	options := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.validation.Leeway),
+	}
+	if v.validation.Issuer != "" {
+		options = append(options, jwt.WithIssuer(v.validation.Issuer))
+	}
+	if v.validation.Audience != "" {
+		options = append(options, jwt.WithAudience(v.validation.Audience))
 	}
 
-	// Extract user_id from claims
-	expTime, ok := claims["exp"].(float64)
-	slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})).Debug(fmt.Sprintf("%v", expTime))
-	if !ok {
-		return 0, 0, domain.ErrInvalidTokenClaims
+	var claims tokenClaims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, v.checkedKeyFunc, options...)
+	if err != nil {
+		return Claims{}, classifyErr(op, err)
+	}
+	if !token.Valid {
+		return Claims{}, domain.ErrTokenIsNotValid
 	}
 
-	if time.Now().Unix() > int64(expTime) {
-		return 0, 0, domain.ErrTokenIsExpired
+	if claims.UserID == nil {
+		return Claims{}, domain.ErrUserIDClaimNotFound
 	}
 
-	// Extract user_id from claims
-	userID, ok := claims["user_id"].(float64)
-	if !ok {
-		return 0, 0, domain.ErrUserIDClaimNotFound
+	return Claims{
+		UserID:    *claims.UserID,
+		Roles:     claims.Roles,
+		ClubIDs:   claims.ClubIDs,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// checkedKeyFunc rejects the tokens signed with an algorithm the verifier does not accept before looking up the key
+func (v *Verifier) checkedKeyFunc(token *jwt.Token) (interface{}, error) {
+	if !slices.Contains(v.methods, token.Method.Alg()) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnexpectedSigningMethod, token.Method.Alg())
 	}
+	return v.keyFunc(token)
+}
 
-	return int64(userID), int64(expTime), nil
+// classifyErr maps the parser errors to the domain token errors
+func classifyErr(op string, err error) error {
+	switch {
+	case errors.Is(err, ErrKeyUnavailable):
+		return fmt.Errorf("%s: %w", op, err)
+	case errors.Is(err, domain.ErrUnexpectedSigningMethod):
+		return domain.ErrUnexpectedSigningMethod
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return domain.ErrTokenIsExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return domain.ErrTokenNotValidYet
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return domain.ErrTokenInvalidIssuer
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return domain.ErrTokenInvalidAudience
+	case errors.Is(err, jwt.ErrTokenRequiredClaimMissing), errors.Is(err, jwt.ErrTokenInvalidClaims):
+		return domain.ErrInvalidTokenClaims
+	case errors.Is(err, jwt.ErrTokenMalformed),
+		errors.Is(err, jwt.ErrTokenSignatureInvalid),
+		errors.Is(err, jwt.ErrTokenUnverifiable),
+		errors.Is(err, ErrKeyNotFound):
+		return domain.ErrTokenIsNotValid
+	default:
+		return fmt.Errorf("%s: %w", op, err)
+	}
 }
@@ -0,0 +1,99 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func hmacToken(t *testing.T, claims jwt.MapClaims) string {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return signed
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	verifier := NewHMACVerifier(testSecret).WithValidation(Validation{Issuer: "uniclubs", Audience: "comments"})
+
+	claims, err := verifier.Verify(hmacToken(t, jwt.MapClaims{
+		"user_id":  7,
+		"roles":    []string{"MODER"},
+		"club_ids": []int64{1, 2},
+		"exp":      expiresAt.Unix(),
+		"iss":      "uniclubs",
+		"aud":      []string{"posts", "comments"},
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, Claims{UserID: 7, Roles: []string{"MODER"}, ClubIDs: []int64{1, 2}, ExpiresAt: expiresAt}, claims)
+	assert.True(t, claims.HasRole("ADMIN", "MODER"))
+}
+
+func TestVerifier_Verify_FailPath(t *testing.T) {
+	now := time.Now()
+	valid := func(overrides jwt.MapClaims) jwt.MapClaims {
+		claims := jwt.MapClaims{
+			"user_id": 7,
+			"exp":     now.Add(time.Hour).Unix(),
+			"iss":     "uniclubs",
+			"aud":     "comments",
+		}
+		for key, value := range overrides {
+			if value == nil {
+				delete(claims, key)
+				continue
+			}
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedError error
+	}{
+		{name: "malformed", token: "abc", expectedError: domain.ErrTokenIsNotValid},
+		{name: "expired", token: hmacToken(t, valid(jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()})), expectedError: domain.ErrTokenIsExpired},
+		{name: "no expiry", token: hmacToken(t, valid(jwt.MapClaims{"exp": nil})), expectedError: domain.ErrInvalidTokenClaims},
+		{name: "not valid yet", token: hmacToken(t, valid(jwt.MapClaims{"nbf": now.Add(time.Minute).Unix()})), expectedError: domain.ErrTokenNotValidYet},
+		{name: "invalid issuer", token: hmacToken(t, valid(jwt.MapClaims{"iss": "other"})), expectedError: domain.ErrTokenInvalidIssuer},
+		{name: "invalid audience", token: hmacToken(t, valid(jwt.MapClaims{"aud": "posts"})), expectedError: domain.ErrTokenInvalidAudience},
+		{name: "no user id", token: hmacToken(t, valid(jwt.MapClaims{"user_id": nil})), expectedError: domain.ErrUserIDClaimNotFound},
+		{name: "wrong secret", token: func() string {
+			signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, valid(nil)).SignedString([]byte("other"))
+			require.NoError(t, err)
+			return signed
+		}(), expectedError: domain.ErrTokenIsNotValid},
+	}
+
+	verifier := NewHMACVerifier(testSecret).WithValidation(Validation{Issuer: "uniclubs", Audience: "comments"})
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.Verify(tc.token)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestVerifier_Verify_Leeway(t *testing.T) {
+	token := hmacToken(t, jwt.MapClaims{
+		"user_id": 7,
+		"exp":     time.Now().Add(-10 * time.Second).Unix(),
+		"nbf":     time.Now().Add(10 * time.Second).Unix(),
+	})
+
+	_, err := NewHMACVerifier(testSecret).Verify(token)
+	assert.Error(t, err)
+
+	claims, err := NewHMACVerifier(testSecret).WithValidation(Validation{Leeway: 30 * time.Second}).Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), claims.UserID)
+}
@@ -0,0 +1,287 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrKeyUnavailable is returned when the verification key cannot be loaded, e.g. the JWKS endpoint is down.
+// The token itself may be valid, so callers treat it as an internal error.
+var ErrKeyUnavailable = errors.New("verification key is unavailable")
+
+// ErrKeyNotFound is returned when the key set has no key with the kid of the token
+var ErrKeyNotFound = errors.New("verification key not found")
+
+// asymmetricMethods are the signing algorithms accepted for the public keys
+var asymmetricMethods = []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}
+
+// NewPublicKeyVerifier returns the verifier of the tokens signed with the private pair of the RSA or ECDSA public key
+func NewPublicKeyVerifier(pemBytes []byte) (*Verifier, error) {
+	key, err := parsePublicKey(pemBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Verifier{
+		keyFunc: func(token *jwt.Token) (interface{}, error) {
+			return key, nil
+		},
+		methods: asymmetricMethods,
+	}, nil
+}
+
+// NewPublicKeyFileVerifier reads the PEM encoded public key from the file
+func NewPublicKeyFileVerifier(path string) (*Verifier, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key file: %w", err)
+	}
+
+	return NewPublicKeyVerifier(pemBytes)
+}
+
+// NewJWKSVerifier returns the verifier of the tokens signed with the keys of the key set, picked by the kid header
+func NewJWKSVerifier(keySet *JWKS) *Verifier {
+	return &Verifier{
+		keyFunc: func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return keySet.Key(context.Background(), kid)
+		},
+		methods: asymmetricMethods,
+	}
+}
+
+func parsePublicKey(pemBytes []byte) (any, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(pemBytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("public key must be a PEM encoded RSA or ECDSA public key")
+}
+
+// JWKS is the key set fetched from a JWKS endpoint.
+// The keys are cached and fetched again after the refresh interval, or earlier when a token has an unknown kid,
+// so rotated keys are picked up without a restart.
+// The endpoint is asked at most once per minimum refresh interval, failed attempts included,
+// and concurrent callers wait for the same fetch instead of starting their own.
+type JWKS struct {
+	url        string
+	client     *http.Client
+	refresh    time.Duration
+	minRefresh time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]any
+	fetchedAt time.Time
+	// attemptedAt is the time of the last fetch, successful or not, and lastErr its error
+	attemptedAt time.Time
+	lastErr     error
+	// inflight is the fetch in progress, nil when there is none
+	inflight *jwksFetch
+}
+
+// jwksFetch is a fetch of the key set shared by the callers waiting for it
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// JWKSMinRefreshInterval limits how often tokens with unknown kids make the key set to be fetched again
+const JWKSMinRefreshInterval = time.Minute
+
+func NewJWKS(url string, refreshInterval time.Duration) *JWKS {
+	return &JWKS{
+		url:        url,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		refresh:    refreshInterval,
+		minRefresh: min(JWKSMinRefreshInterval, refreshInterval),
+		keys:       make(map[string]any),
+	}
+}
+
+// Key returns the public key with the kid
+func (s *JWKS) Key(ctx context.Context, kid string) (any, error) {
+	s.mu.Lock()
+	key, ok := s.keys[kid]
+	if ok && time.Since(s.fetchedAt) <= s.refresh {
+		s.mu.Unlock()
+		return key, nil
+	}
+
+	call := s.inflight
+	if call == nil {
+		if time.Since(s.attemptedAt) <= s.minRefresh {
+			// the endpoint was asked recently, keep using the cached keys until the interval passes
+			lastErr := s.lastErr
+			s.mu.Unlock()
+			return cachedKey(kid, key, ok, lastErr)
+		}
+
+		call = &jwksFetch{done: make(chan struct{})}
+		s.inflight = call
+		s.attemptedAt = time.Now()
+		s.mu.Unlock()
+
+		s.refreshKeys(ctx, call)
+	} else {
+		s.mu.Unlock()
+	}
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		return cachedKey(kid, key, ok, fmt.Errorf("%w: %w", ErrKeyUnavailable, ctx.Err()))
+	}
+
+	s.mu.Lock()
+	fetchedKey, found := s.keys[kid]
+	s.mu.Unlock()
+	if found {
+		return fetchedKey, nil
+	}
+	return cachedKey(kid, key, ok, call.err)
+}
+
+// refreshKeys fetches the key set without holding the lock and stores the result for the waiting callers
+func (s *JWKS) refreshKeys(ctx context.Context, call *jwksFetch) {
+	keys, err := s.fetch(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		s.keys = keys
+		s.fetchedAt = time.Now()
+	}
+	s.lastErr = err
+	s.inflight = nil
+	call.err = err
+	close(call.done)
+}
+
+// cachedKey returns the cached key while the endpoint is unavailable, or the error of the last fetch
+func cachedKey(kid string, key any, ok bool, fetchErr error) (any, error) {
+	if ok {
+		return key, nil
+	}
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	return nil, fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
+}
+
+// fetch returns the keys of the endpoint
+func (s *JWKS) fetch(ctx context.Context) (map[string]any, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyUnavailable, err)
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyUnavailable, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: jwks endpoint returned %s", ErrKeyUnavailable, response.Status)
+	}
+
+	var body struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyUnavailable, err)
+	}
+
+	keys := make(map[string]any, len(body.Keys))
+	for _, jwk := range body.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// a key of an unsupported type must not hide the other keys of the set
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// jsonWebKey is a public key of the key set as defined in RFC 7517
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	// N and E are the modulus and exponent of an RSA key
+	N string `json:"n"`
+	E string `json:"e"`
+	// Crv, X and Y are the curve and coordinates of an EC key
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
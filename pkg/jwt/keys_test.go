@@ -0,0 +1,218 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ARUMANDESU/uniclubs-comments-service/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signToken(t *testing.T, method jwt.SigningMethod, key crypto.Signer, kid string, userID int64) string {
+	token := jwt.NewWithClaims(method, jwt.MapClaims{
+		"user_id": userID,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func publicKeyPEM(t *testing.T, key crypto.PublicKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "RSA",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func TestPublicKeyVerifier(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("RS256", func(t *testing.T) {
+		verifier, err := NewPublicKeyVerifier(publicKeyPEM(t, &rsaKey.PublicKey))
+		require.NoError(t, err)
+
+		claims, err := verifier.Verify(signToken(t, jwt.SigningMethodRS256, rsaKey, "", 7))
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), claims.UserID)
+		assert.False(t, claims.ExpiresAt.IsZero())
+	})
+
+	t.Run("ES256", func(t *testing.T) {
+		verifier, err := NewPublicKeyVerifier(publicKeyPEM(t, &ecKey.PublicKey))
+		require.NoError(t, err)
+
+		claims, err := verifier.Verify(signToken(t, jwt.SigningMethodES256, ecKey, "", 8))
+		require.NoError(t, err)
+		assert.Equal(t, int64(8), claims.UserID)
+	})
+
+	t.Run("HMAC token is rejected", func(t *testing.T) {
+		verifier, err := NewPublicKeyVerifier(publicKeyPEM(t, &rsaKey.PublicKey))
+		require.NoError(t, err)
+
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 1}).SignedString(publicKeyPEM(t, &rsaKey.PublicKey))
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token)
+		assert.ErrorIs(t, err, domain.ErrUnexpectedSigningMethod)
+	})
+
+	t.Run("invalid PEM", func(t *testing.T) {
+		_, err := NewPublicKeyVerifier([]byte("not a key"))
+		assert.Error(t, err)
+	})
+}
+
+func TestJWKSVerifier_KeyRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var (
+		rotated  atomic.Bool
+		requests atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		keys := []map[string]string{rsaJWK("old", &oldKey.PublicKey)}
+		if rotated.Load() {
+			keys = append(keys, rsaJWK("new", &newKey.PublicKey))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+	}))
+	defer server.Close()
+
+	keySet := NewJWKS(server.URL, time.Hour)
+	keySet.minRefresh = 0
+	verifier := NewJWKSVerifier(keySet)
+
+	claims, err := verifier.Verify(signToken(t, jwt.SigningMethodRS256, oldKey, "old", 7))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), claims.UserID)
+
+	// the cached key is used without fetching the key set again
+	_, err = verifier.Verify(signToken(t, jwt.SigningMethodRS256, oldKey, "old", 7))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// a token with an unknown kid makes the key set to be fetched again
+	rotated.Store(true)
+	claims, err = verifier.Verify(signToken(t, jwt.SigningMethodRS256, newKey, "new", 8))
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), claims.UserID)
+	assert.Equal(t, int32(2), requests.Load())
+
+	_, err = verifier.Verify(signToken(t, jwt.SigningMethodRS256, newKey, "unknown", 8))
+	assert.ErrorIs(t, err, domain.ErrTokenIsNotValid)
+}
+
+func TestJWKSVerifier_Unavailable(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	verifier := NewJWKSVerifier(NewJWKS(server.URL, time.Hour))
+
+	_, err = verifier.Verify(signToken(t, jwt.SigningMethodRS256, key, "kid", 7))
+	assert.ErrorIs(t, err, ErrKeyUnavailable)
+}
+
+func TestJWKSVerifier_Unavailable_BacksOff(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	verifier := NewJWKSVerifier(NewJWKS(server.URL, time.Hour))
+
+	for range 3 {
+		_, err = verifier.Verify(signToken(t, jwt.SigningMethodRS256, key, "kid", 7))
+		assert.ErrorIs(t, err, ErrKeyUnavailable)
+	}
+	// the failed attempt is not repeated until the minimum refresh interval passes
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestJWKS_Key_ConcurrentFetch(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{rsaJWK("kid", &key.PublicKey)}})
+	}))
+	defer server.Close()
+
+	keySet := NewJWKS(server.URL, time.Hour)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keySet.Key(context.Background(), "kid")
+			errs <- err
+		}()
+	}
+
+	// the callers wait for the fetch of the first one, the lock is not held while it runs
+	require.Eventually(t, func() bool { return requests.Load() == 1 }, time.Second, 10*time.Millisecond)
+	keySet.mu.Lock()
+	assert.NotNil(t, keySet.inflight)
+	keySet.mu.Unlock()
+	close(release)
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), requests.Load())
+}
@@ -0,0 +1,93 @@
+// Package origin checks the Origin header of the browser requests against the allowed origins.
+package origin
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Checker matches origins against the allowed list.
+//
+// An allowed origin is either exact, e.g. https://uniclubs.example.com, or a wildcard of the subdomains,
+// e.g. https://*.example.com, which matches https://app.example.com and https://a.b.example.com but not
+// https://example.com. A single "*" allows every origin.
+type Checker struct {
+	allowAll   bool
+	allowEmpty bool
+	exact      map[string]struct{}
+	wildcards  []wildcard
+}
+
+type wildcard struct {
+	scheme string
+	// suffix is the host with the port after the wildcard, including the leading dot
+	suffix string
+}
+
+// NewChecker parses the allowed origins, allowEmpty accepts the requests without the Origin header
+// and the "null" origin of sandboxed pages and local files
+func NewChecker(allowed []string, allowEmpty bool) (*Checker, error) {
+	c := &Checker{
+		allowEmpty: allowEmpty,
+		exact:      make(map[string]struct{}, len(allowed)),
+	}
+
+	for _, o := range allowed {
+		o = strings.ToLower(strings.TrimSpace(o))
+		if o == "" {
+			continue
+		}
+		if o == "*" {
+			c.allowAll = true
+			continue
+		}
+
+		scheme, host, ok := strings.Cut(o, "://")
+		if !ok || scheme == "" || host == "" || strings.ContainsAny(host, "/?#") {
+			return nil, fmt.Errorf("invalid allowed origin %q, expected scheme://host[:port]", o)
+		}
+
+		if suffix, ok := strings.CutPrefix(host, "*."); ok {
+			if suffix == "" || strings.Contains(suffix, "*") {
+				return nil, fmt.Errorf("invalid allowed origin %q, the wildcard must be the whole first label", o)
+			}
+			c.wildcards = append(c.wildcards, wildcard{scheme: scheme, suffix: "." + suffix})
+			continue
+		}
+		if strings.Contains(host, "*") {
+			return nil, fmt.Errorf("invalid allowed origin %q, the wildcard must be the whole first label", o)
+		}
+
+		c.exact[scheme+"://"+host] = struct{}{}
+	}
+
+	return c, nil
+}
+
+// Allowed reports whether the origin is allowed
+func (c *Checker) Allowed(origin string) bool {
+	if origin == "" || origin == "null" {
+		return c.allowEmpty
+	}
+	if c.allowAll {
+		return true
+	}
+
+	u, err := url.Parse(strings.ToLower(origin))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return false
+	}
+
+	if _, ok := c.exact[u.Scheme+"://"+u.Host]; ok {
+		return true
+	}
+
+	for _, w := range c.wildcards {
+		if u.Scheme == w.scheme && strings.HasSuffix(u.Host, w.suffix) && len(u.Host) > len(w.suffix) {
+			return true
+		}
+	}
+
+	return false
+}
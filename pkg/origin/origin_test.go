@@ -0,0 +1,77 @@
+package origin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Allowed(t *testing.T) {
+	checker, err := NewChecker([]string{"http://localhost:3000", "https://*.uniclubs.kz", " HTTPS://Admin.Example.com "}, false)
+	require.NoError(t, err)
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{origin: "http://localhost:3000", allowed: true},
+		{origin: "http://localhost:3001"},
+		{origin: "https://localhost:3000"},
+		{origin: "https://app.uniclubs.kz", allowed: true},
+		{origin: "https://staging.app.uniclubs.kz", allowed: true},
+		{origin: "https://APP.uniclubs.kz", allowed: true},
+		{origin: "https://uniclubs.kz"},
+		{origin: "https://eviluniclubs.kz"},
+		{origin: "https://app.uniclubs.kz.evil.com"},
+		{origin: "http://app.uniclubs.kz"},
+		{origin: "https://app.uniclubs.kz:8443"},
+		{origin: "https://admin.example.com", allowed: true},
+		{origin: ""},
+		{origin: "null"},
+		{origin: "not an origin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, checker.Allowed(tt.origin))
+		})
+	}
+}
+
+func TestChecker_Allowed_Empty(t *testing.T) {
+	checker, err := NewChecker(nil, true)
+	require.NoError(t, err)
+
+	assert.True(t, checker.Allowed(""))
+	assert.True(t, checker.Allowed("null"))
+	assert.False(t, checker.Allowed("http://localhost:3000"))
+}
+
+func TestChecker_Allowed_All(t *testing.T) {
+	checker, err := NewChecker([]string{"*"}, false)
+	require.NoError(t, err)
+
+	assert.True(t, checker.Allowed("https://any.example.com"))
+	assert.False(t, checker.Allowed(""))
+}
+
+func TestNewChecker_FailPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		origin string
+	}{
+		{name: "missing scheme", origin: "localhost:3000"},
+		{name: "path", origin: "https://example.com/app"},
+		{name: "wildcard in the middle", origin: "https://app.*.example.com"},
+		{name: "partial wildcard", origin: "https://app*.example.com"},
+		{name: "bare wildcard host", origin: "https://*."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewChecker([]string{tt.origin}, true)
+			assert.Error(t, err)
+		})
+	}
+}